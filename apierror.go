@@ -0,0 +1,211 @@
+package paapi5
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIError is returned (wrapped) by Client.RequestContext when the Creators
+// API answers with a non-2xx HTTP status. It carries the decoded error code
+// and message of the reply and can be matched with errors.Is against
+// ErrHTTPStatus and the more specific sentinels (ErrTooManyRequests,
+// ErrInvalidPartnerTag, ErrItemNotAccessible, ErrUnauthorized).
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+	Operation  Operation
+	// RetryAfter is the delay announced by the Retry-After response header,
+	// or zero if the header is absent.
+	RetryAfter time.Duration
+}
+
+// requestIDHeaders lists the response headers that may carry the request ID,
+// in order of preference.
+var requestIDHeaders = []string{
+	"x-amzn-RequestId",
+	"x-amz-request-id",
+	"x-request-id",
+}
+
+// apiErrorCodeMap maps Creators API error codes to sentinel errors.
+var apiErrorCodeMap = map[string]Error{
+	"TooManyRequests":       ErrTooManyRequests,
+	"RequestThrottled":      ErrTooManyRequests,
+	"InvalidPartnerTag":     ErrInvalidPartnerTag,
+	"ItemNotAccessible":     ErrItemNotAccessible,
+	"Unauthorized":          ErrUnauthorized,
+	"UnauthorizedOperation": ErrUnauthorized,
+	"UnrecognizedClient":    ErrUnauthorized,
+	"InvalidToken":          ErrUnauthorized,
+	"ExpiredToken":          ErrUnauthorized,
+	"AccessDenied":          ErrUnauthorized,
+}
+
+// apiErrorBody mirrors the error reply of the Creators API. Keys are matched
+// case-insensitively, so both PA-API v5 style (`Errors`/`Code`) and Creators
+// API style (`errors`/`code`) bodies decode.
+type apiErrorBody struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+	Errors  []struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// newAPIError builds an APIError from a non-2xx response and its body.
+func newAPIError(op Operation, resp *http.Response, body []byte) *APIError {
+	e := &APIError{Operation: op}
+	if resp == nil {
+		return e
+	}
+	e.StatusCode = resp.StatusCode
+	for _, h := range requestIDHeaders {
+		if id := resp.Header.Get(h); len(id) > 0 {
+			e.RequestID = id
+			break
+		}
+	}
+	e.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+	eb := apiErrorBody{}
+	if err := json.Unmarshal(body, &eb); err != nil {
+		return e
+	}
+	if len(eb.Errors) > 0 {
+		e.Code = eb.Errors[0].Code
+		e.Message = eb.Errors[0].Message
+	}
+	if len(e.Code) == 0 && len(eb.Type) > 0 {
+		// e.g. "com.amazon.paapi5#TooManyRequestsException"
+		code := eb.Type
+		if i := strings.LastIndex(code, "#"); i >= 0 {
+			code = code[i+1:]
+		}
+		e.Code = strings.TrimSuffix(code, "Exception")
+	}
+	if len(e.Message) == 0 {
+		e.Message = eb.Message
+	}
+	return e
+}
+
+// parseRetryAfter parses a Retry-After header value given either as
+// delay-seconds or as an HTTP-date. It returns zero for absent or malformed
+// values.
+func parseRetryAfter(v string) time.Duration {
+	v = strings.TrimSpace(v)
+	if len(v) == 0 {
+		return 0
+	}
+	if sec, err := strconv.Atoi(v); err == nil {
+		if sec > 0 {
+			return time.Duration(sec) * time.Second
+		}
+		return 0
+	}
+	if tm, err := http.ParseTime(v); err == nil {
+		if d := time.Until(tm); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// Error method returns error message.
+// This method is a implementation of error interface.
+func (e *APIError) Error() string {
+	if e == nil {
+		return ErrNullPointer.Error()
+	}
+	b := strings.Builder{}
+	if s := e.Operation.String(); len(s) > 0 {
+		b.WriteString(s)
+		b.WriteString(": ")
+	}
+	b.WriteString("HTTP ")
+	b.WriteString(strconv.Itoa(e.StatusCode))
+	if len(e.Code) > 0 {
+		b.WriteString(" ")
+		b.WriteString(e.Code)
+	}
+	if len(e.Message) > 0 {
+		b.WriteString(": ")
+		b.WriteString(e.Message)
+	}
+	if len(e.RequestID) > 0 {
+		b.WriteString(" (request id: ")
+		b.WriteString(e.RequestID)
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+// Unwrap method returns ErrHTTPStatus, so every APIError matches it with errors.Is.
+func (e *APIError) Unwrap() error {
+	return ErrHTTPStatus
+}
+
+// Is method reports whether the APIError matches the target sentinel error.
+// The error code is consulted first; the HTTP status is used as a fallback.
+func (e *APIError) Is(target error) bool {
+	if e == nil {
+		return false
+	}
+	code, ok := target.(Error)
+	if !ok {
+		return false
+	}
+	return e.sentinel() == code
+}
+
+// invalidTokenCodes lists the error codes reporting a rejected access token.
+var invalidTokenCodes = map[string]bool{
+	"InvalidToken": true,
+	"ExpiredToken": true,
+}
+
+// isInvalidToken reports whether err is an APIError rejecting the access
+// token, i.e. an HTTP 401 reply or an invalid-token error code.
+func isInvalidToken(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusUnauthorized || invalidTokenCodes[apiErr.Code]
+}
+
+// sentinel returns the sentinel error matching the APIError, or ErrHTTPStatus
+// when there is no more specific one.
+func (e *APIError) sentinel() Error {
+	if err, ok := apiErrorCodeMap[e.Code]; ok {
+		return err
+	}
+	switch e.StatusCode {
+	case http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	}
+	return ErrHTTPStatus
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
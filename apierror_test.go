@@ -0,0 +1,114 @@
+package paapi5
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestNewAPIError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status int
+		header http.Header
+		body   string
+		code   string
+		msg    string
+		reqID  string
+		is     error
+	}{
+		{
+			name:   "creators api body",
+			status: http.StatusTooManyRequests,
+			header: http.Header{"X-Amzn-Requestid": {"req-1"}},
+			body:   `{"errors":[{"code":"TooManyRequests","message":"throttled"}]}`,
+			code:   "TooManyRequests",
+			msg:    "throttled",
+			reqID:  "req-1",
+			is:     ErrTooManyRequests,
+		},
+		{
+			name:   "pa-api v5 body",
+			status: http.StatusBadRequest,
+			body:   `{"__type":"com.amazon.paapi5#InvalidParameterValueException","Errors":[{"Code":"InvalidPartnerTag","Message":"bad tag"}]}`,
+			code:   "InvalidPartnerTag",
+			msg:    "bad tag",
+			is:     ErrInvalidPartnerTag,
+		},
+		{
+			name:   "type only",
+			status: http.StatusNotFound,
+			body:   `{"__type":"com.amazon.paapi5#ItemNotAccessibleException","message":"not accessible"}`,
+			code:   "ItemNotAccessible",
+			msg:    "not accessible",
+			is:     ErrItemNotAccessible,
+		},
+		{
+			name:   "status fallback",
+			status: http.StatusUnauthorized,
+			body:   `{"message":"Unauthorized"}`,
+			code:   "",
+			msg:    "Unauthorized",
+			is:     ErrUnauthorized,
+		},
+		{
+			name:   "not json",
+			status: http.StatusBadGateway,
+			body:   `<html>bad gateway</html>`,
+			is:     ErrHTTPStatus,
+		},
+	}
+	for _, tc := range testCases {
+		resp := &http.Response{StatusCode: tc.status, Header: tc.header}
+		if resp.Header == nil {
+			resp.Header = http.Header{}
+		}
+		e := newAPIError(GetItems, resp, []byte(tc.body))
+		if e.StatusCode != tc.status {
+			t.Errorf("%s: StatusCode = %d, want %d", tc.name, e.StatusCode, tc.status)
+		}
+		if e.Code != tc.code {
+			t.Errorf("%s: Code = %q, want %q", tc.name, e.Code, tc.code)
+		}
+		if e.Message != tc.msg {
+			t.Errorf("%s: Message = %q, want %q", tc.name, e.Message, tc.msg)
+		}
+		if e.RequestID != tc.reqID {
+			t.Errorf("%s: RequestID = %q, want %q", tc.name, e.RequestID, tc.reqID)
+		}
+		if e.Operation != GetItems {
+			t.Errorf("%s: Operation = %v, want %v", tc.name, e.Operation, GetItems)
+		}
+		if !errors.Is(e, tc.is) {
+			t.Errorf("%s: errors.Is(%v, %v) is false", tc.name, e, tc.is)
+		}
+		if !errors.Is(e, ErrHTTPStatus) {
+			t.Errorf("%s: errors.Is(%v, ErrHTTPStatus) is false", tc.name, e)
+		}
+	}
+}
+
+func TestAPIErrorString(t *testing.T) {
+	e := &APIError{StatusCode: 429, Code: "TooManyRequests", Message: "throttled", RequestID: "req-1", Operation: SearchItems}
+	if got, want := e.Error(), "SearchItems: HTTP 429 TooManyRequests: throttled (request id: req-1)"; got != want {
+		t.Errorf("APIError.Error() = %q, want %q", got, want)
+	}
+	if errors.Is(e, ErrInvalidPartnerTag) {
+		t.Errorf("errors.Is(%v, ErrInvalidPartnerTag) is true, want false", e)
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
@@ -31,11 +32,90 @@ const (
 	// maxTokenBodyContextBytes caps how much of the body we attach to error
 	// contexts so we don't propagate arbitrary endpoint output into logs.
 	maxTokenBodyContextBytes = 256
+	// tokenRefreshTimeout bounds a token refresh, which is detached from the
+	// cancellation of the request that triggered it.
+	tokenRefreshTimeout = 1 * time.Minute
+	// tokenRefreshRetryInterval is the delay before retrying a failed
+	// proactive refresh while the current token is still valid.
+	tokenRefreshRetryInterval = 10 * time.Second
 )
 
+// TokenSource is the interface implemented by providers of OAuth2 access
+// tokens for the Creators API catalog endpoints. It mirrors the spirit of
+// golang.org/x/oauth2.TokenSource, but takes a context and returns the bare
+// access token. Implementations must be safe for concurrent use and are
+// expected to cache tokens themselves.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// tokenInvalidator is an optional interface satisfied by TokenSource
+// implementations that cache tokens and can drop one the Creators API has
+// rejected. The built-in client_credentials source satisfies it; custom
+// implementations may opt in to get a fresh token and a single replay of
+// the request after an HTTP 401 reply.
+type tokenInvalidator interface {
+	InvalidateToken(token string)
+}
+
+// TokenSourceFunc type is an adapter to allow the use of ordinary functions
+// as TokenSource.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+var _ TokenSource = TokenSourceFunc(nil)      //TokenSourceFunc is compatible with TokenSource interface
+var _ TokenSource = (*tokenManager)(nil)      //tokenManager is compatible with TokenSource interface
+var _ tokenInvalidator = (*tokenManager)(nil) //tokenManager is compatible with tokenInvalidator interface
+
+// Token method calls f(ctx).
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	if f == nil {
+		return "", errs.Wrap(ErrNullPointer)
+	}
+	return f(ctx)
+}
+
+// StaticTokenSource returns a TokenSource that always returns the supplied
+// access token. Useful for tests and short-lived tools.
+func StaticTokenSource(token string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		if len(token) == 0 {
+			return "", errs.Wrap(ErrNoData, errs.WithContext("reason", "empty access token"))
+		}
+		return token, nil
+	})
+}
+
+// NewTokenSource returns a TokenSource running the OAuth2 client_credentials
+// grant of the given Creators API credential version against endpoint (the
+// default endpoint of the version when empty). Tokens are cached in memory,
+// so one instance can be shared by many Clients through WithTokenSource.
+// httpClient may be nil, in which case http.DefaultClient is used.
+func NewTokenSource(httpClient *http.Client, endpoint, version, credentialID, credentialSecret string) TokenSource {
+	if len(endpoint) == 0 {
+		endpoint = AuthEndpointFor(version)
+	}
+	return newTokenManager(httpClient, endpoint, credentialID, credentialSecret, isLWACredentialVersion(version))
+}
+
+// WithTokenSource function returns a ClientOptFunc that replaces the built-in
+// OAuth2 client_credentials flow with the supplied TokenSource. The
+// credential version still selects the catalog Authorization header shape.
+func WithTokenSource(ts TokenSource) ClientOptFunc {
+	return func(c *client) {
+		if c != nil && ts != nil {
+			c.auth = ts
+		}
+	}
+}
+
 // tokenManager handles the OAuth2 client_credentials flow against a Cognito
 // token endpoint. Tokens are cached in memory and reused across requests until
 // they are within oauthTokenLeewaySeconds of expiring.
+//
+// The lock is never held while talking to the token endpoint: concurrent
+// callers share a single in-flight refresh, and while a proactive refresh
+// (see WithProactiveTokenRefresh) is running they keep using the still-valid
+// old token.
 type tokenManager struct {
 	httpClient   *http.Client
 	endpoint     string
@@ -43,9 +123,41 @@ type tokenManager struct {
 	clientSecret string
 	lwa          bool
 
+	// refreshAhead is the fraction of the token lifetime after which the
+	// token is refreshed proactively. Zero disables proactive refresh.
+	refreshAhead float64
+	// cache optionally persists tokens across processes (see WithTokenCacheDir).
+	cache *fileTokenCache
+	// logger optionally records token events (see WithLogger).
+	logger *slog.Logger
+	// trace optionally observes background refreshes (see WithTrace).
+	trace *ClientTrace
+
 	mu          sync.Mutex
 	accessToken string
 	expiresAt   time.Time
+	refreshAt   time.Time
+	invalid     string // last token rejected by the API
+	inflight    *tokenRefresh
+	timer       stopper
+	closed      bool
+
+	// now and afterFunc are the clock, replaceable in tests.
+	now       func() time.Time
+	afterFunc func(time.Duration, func()) stopper
+}
+
+// stopper is the part of *time.Timer used by tokenManager.
+type stopper interface {
+	Stop() bool
+}
+
+// tokenRefresh is a token refresh in flight, shared by concurrent callers.
+// token and err are valid once done is closed.
+type tokenRefresh struct {
+	done  chan struct{}
+	token string
+	err   error
 }
 
 // newTokenManager constructs a tokenManager. httpClient may be nil, in which
@@ -60,6 +172,25 @@ func newTokenManager(httpClient *http.Client, endpoint, clientID, clientSecret s
 		clientID:     clientID,
 		clientSecret: clientSecret,
 		lwa:          lwa,
+		now:          time.Now,
+		afterFunc: func(d time.Duration, f func()) stopper {
+			return time.AfterFunc(d, f)
+		},
+	}
+}
+
+// WithProactiveTokenRefresh function returns a ClientOptFunc that refreshes
+// the OAuth2 access token in a background goroutine once the given fraction
+// (between 0 and 1, exclusive) of its lifetime has elapsed, instead of
+// waiting until it expires. Requests keep using the still-valid token while
+// the refresh is in flight. Call Close on the client (it implements
+// io.Closer) to stop the background refresh. The option has no effect when
+// the client uses a TokenSource set by WithTokenSource.
+func WithProactiveTokenRefresh(fraction float64) ClientOptFunc {
+	return func(c *client) {
+		if c != nil && fraction > 0 && fraction < 1 {
+			c.tokenRefreshAhead = fraction
+		}
 	}
 }
 
@@ -70,16 +201,185 @@ func (t *tokenManager) Token(ctx context.Context) (string, error) {
 		return "", errs.Wrap(ErrNullPointer)
 	}
 	t.mu.Lock()
+	now := t.now()
+	if t.accessToken != "" && now.Before(t.expiresAt) {
+		token := t.accessToken
+		if !t.refreshAt.IsZero() && !now.Before(t.refreshAt) {
+			t.startRefreshLocked(ctx)
+		}
+		t.mu.Unlock()
+		return token, nil
+	}
+	call := t.startRefreshLocked(ctx)
+	t.mu.Unlock()
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		return "", errs.Wrap(ctx.Err(), errs.WithContext("endpoint", t.endpoint))
+	}
+	if call.err != nil {
+		return "", call.err
+	}
+	return call.token, nil
+}
+
+// InvalidateToken drops the cached access token if it is still the supplied
+// one, so that the next call to Token fetches a new token. Tokens that were
+// already replaced by a concurrent refresh are left alone, and the dropped
+// token is never picked up again from the on-disk cache.
+func (t *tokenManager) InvalidateToken(token string) {
+	if t == nil || len(token) == 0 {
+		return
+	}
+	t.mu.Lock()
+	t.invalid = token
+	if t.accessToken != token {
+		t.mu.Unlock()
+		return
+	}
+	t.accessToken = ""
+	t.expiresAt = time.Time{}
+	t.refreshAt = time.Time{}
+	t.mu.Unlock()
+	t.log(context.Background(), slog.LevelInfo, "pa-api token invalidated")
+}
+
+// Close method stops the background refresh started by
+// WithProactiveTokenRefresh. Token keeps working afterwards, refreshing
+// lazily. This method is a implementation of io.Closer interface.
+func (t *tokenManager) Close() error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
 	defer t.mu.Unlock()
-	if t.accessToken != "" && time.Now().Before(t.expiresAt) {
-		return t.accessToken, nil
+	t.closed = true
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
 	}
-	if err := t.refreshLocked(ctx); err != nil {
+	return nil
+}
+
+// startRefreshLocked starts a refresh unless one is already in flight and
+// returns the in-flight refresh. The refresh is detached from the caller's
+// cancellation, since other callers may be waiting for it too, but is bounded
+// by tokenRefreshTimeout.
+func (t *tokenManager) startRefreshLocked(ctx context.Context) *tokenRefresh {
+	if t.inflight != nil {
+		return t.inflight
+	}
+	call := &tokenRefresh{done: make(chan struct{})}
+	t.inflight = call
+	go t.refresh(context.WithoutCancel(ctx), call, t.expiresAt, t.invalid)
+	return call
+}
+
+// refresh obtains a new token and publishes it to the tokenManager and to
+// the callers waiting on call. If the refresh fails while the current token
+// is still valid, the current token is kept and the refresh is retried after
+// tokenRefreshRetryInterval.
+func (t *tokenManager) refresh(ctx context.Context, call *tokenRefresh, current time.Time, invalid string) {
+	ctx, cancel := context.WithTimeout(ctx, tokenRefreshTimeout)
+	defer cancel()
+	ctx, done := pickTrace(ctx, t.trace).tokenRefresh(ctx)
+	ct, err := t.obtain(ctx, current, invalid)
+	done(err)
+	kept := false // whether the still-valid current token survives a failure
+
+	t.mu.Lock()
+	t.inflight = nil
+	switch {
+	case err == nil:
+		t.accessToken = ct.AccessToken
+		t.expiresAt = ct.ExpiresAt
+		t.refreshAt = t.refreshTime(ct.ExpiresAt)
+		call.token = ct.AccessToken
+	case t.now().Before(t.expiresAt):
+		kept = true
+		if !t.refreshAt.IsZero() {
+			t.refreshAt = t.now().Add(tokenRefreshRetryInterval)
+		}
+	default:
 		t.accessToken = ""
 		t.expiresAt = time.Time{}
-		return "", err
+		t.refreshAt = time.Time{}
+	}
+	t.scheduleLocked()
+	t.mu.Unlock()
+
+	if err == nil {
+		t.log(ctx, slog.LevelInfo, "pa-api token refreshed", slog.Time("expires_at", ct.ExpiresAt))
+	} else {
+		t.log(ctx, slog.LevelWarn, "pa-api token refresh failed", slog.Bool("kept_current", kept), slog.String("error", err.Error()))
+	}
+	call.err = err
+	close(call.done)
+}
+
+// refreshTime returns the instant at which a token expiring at expiresAt
+// should be refreshed proactively, or the zero time if proactive refresh is
+// disabled.
+func (t *tokenManager) refreshTime(expiresAt time.Time) time.Time {
+	if t.refreshAhead <= 0 {
+		return time.Time{}
 	}
-	return t.accessToken, nil
+	now := t.now()
+	return now.Add(time.Duration(float64(expiresAt.Sub(now)) * t.refreshAhead))
+}
+
+// scheduleLocked arms the background timer for the next proactive refresh.
+func (t *tokenManager) scheduleLocked() {
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	if t.closed || t.refreshAt.IsZero() {
+		return
+	}
+	t.timer = t.afterFunc(t.refreshAt.Sub(t.now()), func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if !t.closed {
+			t.startRefreshLocked(context.Background())
+		}
+	})
+}
+
+// obtain returns a new token, consulting the on-disk cache first when one is
+// configured. A cached token is only used if it outlives current, the expiry
+// of the token being replaced, and is not the invalid one; otherwise a fresh token is fetched and
+// persisted while holding the cache entry lock, so that concurrent processes
+// perform a single OAuth2 exchange. The cache is best-effort: lock and write
+// failures fall back to a plain fetch.
+func (t *tokenManager) obtain(ctx context.Context, current time.Time, invalid string) (cachedToken, error) {
+	if t.cache == nil {
+		return t.fetchToken(ctx)
+	}
+	key := t.cache.key(t.clientID, t.endpoint, t.scope())
+	if unlock, err := t.cache.lock(ctx, key); err == nil {
+		defer unlock()
+	} else if ctx.Err() != nil {
+		return cachedToken{}, err
+	}
+	if ct, ok := t.cache.load(key); ok && ct.ExpiresAt.After(current) && ct.AccessToken != invalid {
+		t.log(ctx, slog.LevelDebug, "pa-api token loaded from cache", slog.Time("expires_at", ct.ExpiresAt))
+		return ct, nil
+	}
+	ct, err := t.fetchToken(ctx)
+	if err != nil {
+		return cachedToken{}, err
+	}
+	_ = t.cache.store(key, ct)
+	return ct, nil
+}
+
+// scope returns the OAuth2 scope requested by the token manager.
+func (t *tokenManager) scope() string {
+	if t.lwa {
+		return oauthScopeLWA
+	}
+	return oauthScopeCognito
 }
 
 // tokenResponse mirrors the relevant subset of a Cognito token endpoint reply.
@@ -89,28 +389,28 @@ type tokenResponse struct {
 	TokenType   string `json:"token_type"`
 }
 
-// refreshLocked POSTs the client_credentials grant to the configured token
-// endpoint and stores the resulting access token under the existing lock.
+// fetchToken POSTs the client_credentials grant to the configured token
+// endpoint and returns the resulting access token with its leeway-adjusted
+// expiry. It does not touch the cached state and is called without holding
+// the lock.
 //
-// The token POST is issued via *http.Client directly (not the fetch wrapper)
-// so that we can inspect the HTTP status code and capture a bounded slice of
-// the response body for error diagnostics on non-2xx responses.
-func (t *tokenManager) refreshLocked(ctx context.Context) error {
+// The token POST is issued via *http.Client directly so that we can inspect
+// the HTTP status code and capture a bounded slice of the response body for
+// error diagnostics on non-2xx responses.
+func (t *tokenManager) fetchToken(ctx context.Context) (cachedToken, error) {
 	if len(strings.TrimSpace(t.endpoint)) == 0 {
-		return errs.Wrap(ErrNullPointer, errs.WithContext("reason", "empty OAuth2 token endpoint"))
+		return cachedToken{}, errs.Wrap(ErrNullPointer, errs.WithContext("reason", "empty OAuth2 token endpoint"))
 	}
 	form := url.Values{}
 	form.Set("grant_type", oauthGrantType)
-	if t.lwa {
-		form.Set("scope", oauthScopeLWA)
-	} else {
+	if !t.lwa {
 		form.Set("client_id", t.clientID)
 		form.Set("client_secret", t.clientSecret)
-		form.Set("scope", oauthScopeCognito)
 	}
+	form.Set("scope", t.scope())
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.endpoint, strings.NewReader(form.Encode()))
 	if err != nil {
-		return errs.Wrap(err, errs.WithContext("endpoint", t.endpoint))
+		return cachedToken{}, errs.Wrap(err, errs.WithContext("endpoint", t.endpoint))
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Accept", "application/json")
@@ -120,18 +420,18 @@ func (t *tokenManager) refreshLocked(ctx context.Context) error {
 	}
 	resp, err := t.httpClient.Do(req)
 	if err != nil {
-		return errs.Wrap(err, errs.WithContext("endpoint", t.endpoint))
+		return cachedToken{}, errs.Wrap(err, errs.WithContext("endpoint", t.endpoint))
 	}
 	defer func() { _ = resp.Body.Close() }()
 	body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxTokenBodyReadBytes))
 	if readErr != nil {
-		return errs.Wrap(readErr,
+		return cachedToken{}, errs.Wrap(readErr,
 			errs.WithContext("endpoint", t.endpoint),
 			errs.WithContext("status", resp.StatusCode),
 		)
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return errs.Wrap(
+		return cachedToken{}, errs.Wrap(
 			fmt.Errorf("%w: HTTP %d", ErrHTTPStatus, resp.StatusCode),
 			errs.WithContext("endpoint", t.endpoint),
 			errs.WithContext("status", resp.StatusCode),
@@ -140,14 +440,14 @@ func (t *tokenManager) refreshLocked(ctx context.Context) error {
 	}
 	tr := tokenResponse{}
 	if err := json.Unmarshal(body, &tr); err != nil {
-		return errs.Wrap(err,
+		return cachedToken{}, errs.Wrap(err,
 			errs.WithContext("endpoint", t.endpoint),
 			errs.WithContext("status", resp.StatusCode),
 			errs.WithContext("body", truncateForLog(body, maxTokenBodyContextBytes)),
 		)
 	}
 	if tr.AccessToken == "" {
-		return errs.Wrap(ErrNoData,
+		return cachedToken{}, errs.Wrap(ErrNoData,
 			errs.WithContext("endpoint", t.endpoint),
 			errs.WithContext("status", resp.StatusCode),
 			errs.WithContext("body", truncateForLog(body, maxTokenBodyContextBytes)),
@@ -161,9 +461,10 @@ func (t *tokenManager) refreshLocked(ctx context.Context) error {
 	if leeway >= expiresIn {
 		leeway = expiresIn / 2
 	}
-	t.accessToken = tr.AccessToken
-	t.expiresAt = time.Now().Add(time.Duration(expiresIn-leeway) * time.Second)
-	return nil
+	return cachedToken{
+		AccessToken: tr.AccessToken,
+		ExpiresAt:   t.now().Add(time.Duration(expiresIn-leeway) * time.Second),
+	}, nil
 }
 
 // truncateForLog returns a string copy of b clipped to max bytes, with a
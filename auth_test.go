@@ -0,0 +1,239 @@
+package paapi5
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTokenServer stands up a token endpoint handing out "tok-1", "tok-2", …
+// with the given lifetime. If gate is not nil, every request after the
+// first one waits for a value from gate.
+func newTokenServer(t *testing.T, expiresIn int, gate <-chan struct{}) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n > 1 && gate != nil {
+			<-gate
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("tok-%d", n),
+			"expires_in":   expiresIn,
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+// fakeTimer is a timer of fakeClock, fired by the test.
+type fakeTimer struct {
+	d       time.Duration
+	f       func()
+	stopped atomic.Bool
+}
+
+func (ft *fakeTimer) Stop() bool { return !ft.stopped.Swap(true) }
+
+// fakeClock replaces the clock of tm with a fixed instant and hands every
+// timer armed by tm to the returned channel.
+func fakeClock(tm *tokenManager) <-chan *fakeTimer {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	timers := make(chan *fakeTimer, 8)
+	tm.now = func() time.Time { return now }
+	tm.afterFunc = func(d time.Duration, f func()) stopper {
+		ft := &fakeTimer{d: d, f: f}
+		timers <- ft
+		return ft
+	}
+	return timers
+}
+
+// waitRefresh waits for the refresh in flight in tm, if any.
+func waitRefresh(tm *tokenManager) {
+	tm.mu.Lock()
+	call := tm.inflight
+	tm.mu.Unlock()
+	if call != nil {
+		<-call.done
+	}
+}
+
+func TestTokenManagerSingleflight(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(100 * time.Millisecond)
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "tok", "expires_in": 3600})
+	}))
+	t.Cleanup(srv.Close)
+	tm := newTokenManager(nil, srv.URL, "id", "secret", true)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if tok, err := tm.Token(context.Background()); err != nil || tok != "tok" {
+				t.Errorf("Token() = %q, %v, want \"tok\", nil", tok, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got, want := atomic.LoadInt32(&calls), int32(1); got != want {
+		t.Errorf("token endpoint hit %d times, want %d", got, want)
+	}
+}
+
+func TestTokenManagerWaitHonoursContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		_, _ = io.WriteString(w, `{"access_token":"tok","expires_in":3600}`)
+	}))
+	t.Cleanup(srv.Close)
+	tm := newTokenManager(nil, srv.URL, "id", "secret", true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := tm.Token(ctx); err == nil {
+		t.Error("Token() with expired context succeeded")
+	}
+	// The shared refresh is detached from the canceled caller and still
+	// completes for later callers.
+	if tok, err := tm.Token(context.Background()); err != nil || tok != "tok" {
+		t.Errorf("Token() = %q, %v, want \"tok\", nil", tok, err)
+	}
+}
+
+func TestTokenManagerProactiveRefresh(t *testing.T) {
+	// expires_in 2 with the leeway clamped to 1 gives a usable lifetime of
+	// one second; refreshing at half of it arms the timer for 500ms.
+	gate := make(chan struct{})
+	srv, calls := newTokenServer(t, 2, gate)
+	tm := newTokenManager(nil, srv.URL, "id", "secret", true)
+	tm.refreshAhead = 0.5
+	timers := fakeClock(tm)
+	defer func() { _ = tm.Close() }()
+
+	if tok, err := tm.Token(context.Background()); err != nil || tok != "tok-1" {
+		t.Fatalf("Token() = %q, %v, want \"tok-1\", nil", tok, err)
+	}
+	timer := <-timers
+	if timer.d != 500*time.Millisecond {
+		t.Errorf("refresh timer = %v, want 500ms", timer.d)
+	}
+	timer.f()
+	// The background refresh is in flight (the endpoint waits on gate);
+	// callers must not block and keep getting the old, still-valid token.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if tok, err := tm.Token(context.Background()); err != nil || tok != "tok-1" {
+			t.Errorf("Token() during refresh = %q, %v, want \"tok-1\", nil", tok, err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Token() blocked on the background refresh")
+	}
+	gate <- struct{}{}
+	waitRefresh(tm)
+	if tok, err := tm.Token(context.Background()); err != nil || tok != "tok-2" {
+		t.Errorf("Token() after refresh = %q, %v, want \"tok-2\", nil", tok, err)
+	}
+	if got, want := atomic.LoadInt32(calls), int32(2); got != want {
+		t.Errorf("token endpoint hit %d times, want %d", got, want)
+	}
+	if next := <-timers; next.d != 500*time.Millisecond {
+		t.Errorf("next refresh timer = %v, want 500ms", next.d)
+	}
+}
+
+func TestTokenManagerCloseStopsBackgroundRefresh(t *testing.T) {
+	srv, calls := newTokenServer(t, 2, nil)
+	tm := newTokenManager(nil, srv.URL, "id", "secret", true)
+	tm.refreshAhead = 0.5
+	timers := fakeClock(tm)
+
+	if _, err := tm.Token(context.Background()); err != nil {
+		t.Fatalf("Token(): %v", err)
+	}
+	timer := <-timers
+	if err := tm.Close(); err != nil {
+		t.Fatalf("Close(): %v", err)
+	}
+	if !timer.stopped.Load() {
+		t.Error("Close() did not stop the refresh timer")
+	}
+	// A timer that fired concurrently with Close must not refresh either.
+	timer.f()
+	waitRefresh(tm)
+	if got, want := atomic.LoadInt32(calls), int32(1); got != want {
+		t.Errorf("token endpoint hit %d times after Close, want %d", got, want)
+	}
+}
+
+func TestTokenManagerInvalidateToken(t *testing.T) {
+	srv, calls := newTokenServer(t, 3600, nil)
+	tm := newTokenManager(nil, srv.URL, "id", "secret", true)
+
+	if tok, err := tm.Token(context.Background()); err != nil || tok != "tok-1" {
+		t.Fatalf("Token() = %q, %v, want \"tok-1\", nil", tok, err)
+	}
+	tm.InvalidateToken("tok-1")
+	if tok, err := tm.Token(context.Background()); err != nil || tok != "tok-2" {
+		t.Fatalf("Token() after invalidation = %q, %v, want \"tok-2\", nil", tok, err)
+	}
+	// A late invalidation of the already replaced token is a no-op.
+	tm.InvalidateToken("tok-1")
+	if tok, err := tm.Token(context.Background()); err != nil || tok != "tok-2" {
+		t.Errorf("Token() after stale invalidation = %q, %v, want \"tok-2\", nil", tok, err)
+	}
+	if got, want := atomic.LoadInt32(calls), int32(2); got != want {
+		t.Errorf("token endpoint hit %d times, want %d", got, want)
+	}
+}
+
+func TestClientWithProactiveTokenRefresh(t *testing.T) {
+	c := New().CreateClient("tag", "id", "secret", WithProactiveTokenRefresh(0.8))
+	cc := c.(*client)
+	tm, ok := cc.auth.(*tokenManager)
+	if !ok {
+		t.Fatalf("client.auth is not *tokenManager: %T", cc.auth)
+	}
+	if got, want := tm.refreshAhead, 0.8; got != want {
+		t.Errorf("tokenManager.refreshAhead = %v, want %v", got, want)
+	}
+	if err := cc.Close(); err != nil {
+		t.Errorf("client.Close(): %v", err)
+	}
+	for _, fraction := range []float64{0, 1, -0.5, 1.5} {
+		cc := New().CreateClient("tag", "id", "secret", WithProactiveTokenRefresh(fraction)).(*client)
+		if got := cc.auth.(*tokenManager).refreshAhead; got != 0 {
+			t.Errorf("WithProactiveTokenRefresh(%v): refreshAhead = %v, want 0", fraction, got)
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
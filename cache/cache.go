@@ -0,0 +1,269 @@
+// Package cache caches Creators API responses, keeping offer and price
+// data no longer than the Associates policy allows.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/goark/errs"
+	paapi5 "github.com/goark/pa-api"
+)
+
+const (
+	// MaxOfferTTL is the longest time offer and price data may be cached
+	// under the Associates policy.
+	MaxOfferTTL = 24 * time.Hour
+	// DefaultOfferTTL is the TTL of responses with offer or price data.
+	DefaultOfferTTL = time.Hour
+	// DefaultStaticTTL is the TTL of responses with static data only
+	// (ItemInfo, Images, BrowseNodeInfo and so on).
+	DefaultStaticTTL = 24 * time.Hour
+)
+
+// Cache interface is a store of response bodies with a per-entry TTL.
+// Implementations must be safe for concurrent use. The caching Client
+// treats the cache as best-effort: Set errors are ignored.
+type Cache interface {
+	// Get returns the value of key unless it is missing or expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value under key for ttl.
+	Set(key string, value []byte, ttl time.Duration) error
+	// Delete removes key.
+	Delete(key string) error
+}
+
+// config holds the settings of NewClient.
+type config struct {
+	offerTTL  time.Duration
+	staticTTL time.Duration
+}
+
+// OptFunc type is self-referential function type for NewClient function. (functional options pattern)
+type OptFunc func(*config)
+
+// WithOfferTTL function returns an OptFunc that sets the TTL of responses
+// with offer or price data. Values above MaxOfferTTL are capped; zero or
+// negative values disable caching of such responses.
+func WithOfferTTL(ttl time.Duration) OptFunc {
+	return func(cfg *config) {
+		if cfg != nil {
+			cfg.offerTTL = min(ttl, MaxOfferTTL)
+		}
+	}
+}
+
+// WithStaticTTL function returns an OptFunc that sets the TTL of responses
+// with static data only. Zero or negative values disable caching of such
+// responses.
+func WithStaticTTL(ttl time.Duration) OptFunc {
+	return func(cfg *config) {
+		if cfg != nil {
+			cfg.staticTTL = ttl
+		}
+	}
+}
+
+// client is a paapi5.Client answering repeated queries from a Cache.
+type client struct {
+	paapi5.Client
+	cache Cache
+	cfg   config
+}
+
+var _ io.Closer = (*client)(nil) //client is compatible with io.Closer interface
+
+// NewClient function returns a Client that answers queries from store
+// before calling c. Entries are keyed on the operation, the marketplace
+// and the canonical payload of the query (see query.Query.Payload), and
+// only successful responses are stored.
+//
+// A response is treated as offer data, cached for the offer TTL, if its
+// query requests offer or variation price resources, or filters or sorts
+// search results by price, availability, condition, delivery or merchant.
+// Other responses are cached for the static TTL.
+func NewClient(c paapi5.Client, store Cache, opts ...OptFunc) (paapi5.Client, error) {
+	if c == nil || store == nil {
+		return nil, errs.Wrap(paapi5.ErrNullPointer, errs.WithContext("reason", "nil client or cache"))
+	}
+	cc := &client{Client: c, cache: store, cfg: config{offerTTL: DefaultOfferTTL, staticTTL: DefaultStaticTTL}}
+	for _, opt := range opts {
+		opt(&cc.cfg)
+	}
+	return cc, nil
+}
+
+// Request issues q with a background context.
+func (c *client) Request(q paapi5.Query) ([]byte, error) {
+	return c.RequestContext(context.Background(), q)
+}
+
+// RequestContext returns the cached response of q, or issues q and caches
+// its response.
+func (c *client) RequestContext(ctx context.Context, q paapi5.Query) ([]byte, error) {
+	if q == nil {
+		return c.Client.RequestContext(ctx, q)
+	}
+	op := q.Operation()
+	payload, err := q.Payload()
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("Operation", op.String()))
+	}
+	key := Key(op, c.Marketplace(), payload)
+	if b, ok := c.cache.Get(key); ok {
+		return b, nil
+	}
+	b, err := c.Client.RequestContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if ttl := c.ttl(payload, b); ttl > 0 {
+		_ = c.cache.Set(key, b, ttl)
+	}
+	return b, nil
+}
+
+// RemainingDailyQuota returns the remaining daily quota of the wrapped
+// client (see paapi5.RemainingDailyQuota).
+func (c *client) RemainingDailyQuota() (int, bool) {
+	return paapi5.RemainingDailyQuota(c.Client)
+}
+
+// Close closes the wrapped client if it implements io.Closer. This method
+// is a implementation of io.Closer interface.
+func (c *client) Close() error {
+	if closer, ok := c.Client.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// ttl returns the TTL of the response body to payload.
+func (c *client) ttl(payload, body []byte) time.Duration {
+	if HasOfferData(payload) || hasPriceData(body) {
+		return c.cfg.offerTTL
+	}
+	return c.cfg.staticTTL
+}
+
+// priceItem is the part of an item looked at by hasPriceData. Offers are
+// kept raw, so that their members are skipped rather than decoded.
+type priceItem struct {
+	Offers   json.RawMessage `json:"offers"`
+	OffersV2 json.RawMessage `json:"offersV2"`
+	ItemInfo *struct {
+		TradeInInfo *struct {
+			Price json.RawMessage `json:"price"`
+		} `json:"tradeInInfo"`
+	} `json:"itemInfo"`
+}
+
+// priceResult is a result member of a response holding items.
+type priceResult struct {
+	Items []priceItem `json:"items"`
+}
+
+// hasPriceData function reports whether the response body carries offer
+// or price data the payload did not ask for explicitly: offers of an item,
+// or a trade-in price (requested with the rest of ItemInfo). Bodies that
+// cannot be decoded are treated as price data.
+func hasPriceData(body []byte) bool {
+	res := struct {
+		ItemsResult      *priceResult `json:"itemsResult"`
+		SearchResult     *priceResult `json:"searchResult"`
+		VariationsResult *priceResult `json:"variationsResult"`
+	}{}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return true
+	}
+	for _, r := range []*priceResult{res.ItemsResult, res.SearchResult, res.VariationsResult} {
+		if r == nil {
+			continue
+		}
+		for _, item := range r.Items {
+			if !isEmptyJSON(item.Offers) || !isEmptyJSON(item.OffersV2) {
+				return true
+			}
+			if info := item.ItemInfo; info != nil && info.TradeInInfo != nil && !isEmptyJSON(info.TradeInInfo.Price) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isEmptyJSON reports whether raw is missing, null or an empty object.
+func isEmptyJSON(raw json.RawMessage) bool {
+	switch strings.TrimSpace(string(raw)) {
+	case "", "null", "{}":
+		return true
+	}
+	return false
+}
+
+// Key function returns the cache key of a query of op against marketplace
+// with the given canonical payload. It is the same as paapi5.CacheKey and
+// query.Query.CacheKey.
+func Key(op paapi5.Operation, marketplace string, payload []byte) string {
+	return paapi5.CacheKey(op, marketplace, payload)
+}
+
+// priceResources lists the prefixes of the resources carrying offer or
+// price data.
+var priceResources = []string{"offers", "variationSummary.price"}
+
+// offerFilters lists the payload keys whose presence makes search results
+// depend on offer data.
+var offerFilters = []string{"availability", "condition", "deliveryFlags", "maxPrice", "merchant", "minPrice", "minSavingPercent"}
+
+// HasOfferData function reports whether the response to payload carries
+// offer or price data: the payload requests offers, offersV2 or variation
+// price resources, or filters or sorts by offer attributes. Payloads that
+// cannot be decoded are treated as offer data.
+func HasOfferData(payload []byte) bool {
+	p := map[string]json.RawMessage{}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return true
+	}
+	resources := []string{}
+	if raw, ok := p["resources"]; ok {
+		if err := json.Unmarshal(raw, &resources); err != nil {
+			return true
+		}
+	}
+	for _, r := range resources {
+		for _, prefix := range priceResources {
+			if strings.HasPrefix(r, prefix) {
+				return true
+			}
+		}
+	}
+	for _, k := range offerFilters {
+		if _, ok := p[k]; ok {
+			return true
+		}
+	}
+	sortBy := ""
+	if raw, ok := p["sortBy"]; ok {
+		_ = json.Unmarshal(raw, &sortBy)
+	}
+	return strings.HasPrefix(sortBy, "Price")
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,185 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	paapi5 "github.com/goark/pa-api"
+	"github.com/goark/pa-api/query"
+)
+
+// fakeClient is a paapi5.Client counting its requests.
+type fakeClient struct {
+	marketplace string
+	calls       int
+	err         error
+	body        string // response body, {"itemsResult":{}} if empty
+}
+
+func (c *fakeClient) Marketplace() string { return c.marketplace }
+func (c *fakeClient) PartnerTag() string  { return "mytag-20" }
+func (c *fakeClient) PartnerType() string { return "Associates" }
+func (c *fakeClient) Request(q paapi5.Query) ([]byte, error) {
+	return c.RequestContext(context.Background(), q)
+}
+func (c *fakeClient) RequestContext(ctx context.Context, q paapi5.Query) ([]byte, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	if len(c.body) > 0 {
+		return []byte(c.body), nil
+	}
+	return []byte(`{"itemsResult":{}}`), nil
+}
+
+// ttlRecorder is a Cache recording the TTLs it is given.
+type ttlRecorder struct {
+	*LRU
+	ttls []time.Duration
+}
+
+func (r *ttlRecorder) Set(key string, value []byte, ttl time.Duration) error {
+	r.ttls = append(r.ttls, ttl)
+	return r.LRU.Set(key, value, ttl)
+}
+
+func TestClient(t *testing.T) {
+	fake := &fakeClient{marketplace: "www.amazon.co.jp"}
+	store := &ttlRecorder{LRU: NewLRU(10)}
+	c, err := NewClient(fake, store, WithOfferTTL(48*time.Hour), WithStaticTTL(7*24*time.Hour))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	static := query.NewGetItems("www.amazon.co.jp", "mytag-20", "").ASINs([]string{"4900900028"}).EnableItemInfo()
+	offers := query.NewGetItems("www.amazon.co.jp", "mytag-20", "").ASINs([]string{"4900900028"}).EnableOffersV2()
+	for range 3 {
+		for _, q := range []paapi5.Query{static, offers} {
+			if b, err := c.RequestContext(context.Background(), q); err != nil || string(b) != `{"itemsResult":{}}` {
+				t.Fatalf("RequestContext = %s, %v", b, err)
+			}
+		}
+	}
+	if fake.calls != 2 {
+		t.Errorf("calls = %d, want 2", fake.calls)
+	}
+	if want := []time.Duration{7 * 24 * time.Hour, MaxOfferTTL}; len(store.ttls) != 2 || store.ttls[0] != want[0] || store.ttls[1] != want[1] {
+		t.Errorf("TTLs = %v, want %v", store.ttls, want)
+	}
+
+	fake.err = paapi5.ErrTooManyRequests
+	failing := query.NewGetItems("www.amazon.co.jp", "mytag-20", "").ASINs([]string{"B07YCM5K55"})
+	for range 2 {
+		if _, err := c.RequestContext(context.Background(), failing); !errors.Is(err, paapi5.ErrTooManyRequests) {
+			t.Errorf("RequestContext error = %v, want %v", err, paapi5.ErrTooManyRequests)
+		}
+	}
+	if fake.calls != 4 {
+		t.Errorf("calls = %d, want 4 (failures are not cached)", fake.calls)
+	}
+
+	if _, err := NewClient(nil, NewLRU(1)); !errors.Is(err, paapi5.ErrNullPointer) {
+		t.Errorf("NewClient(nil) error = %v, want %v", err, paapi5.ErrNullPointer)
+	}
+}
+
+func TestClientDisabledTTL(t *testing.T) {
+	fake := &fakeClient{}
+	c, err := NewClient(fake, NewLRU(10), WithOfferTTL(0))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	q := query.NewSearchItems("", "mytag-20", "").Search(query.Keywords, "golang").Request(query.MinPrice, 1000)
+	for range 2 {
+		if _, err := c.Request(q); err != nil {
+			t.Fatalf("Request: %v", err)
+		}
+	}
+	if fake.calls != 2 {
+		t.Errorf("calls = %d, want 2 with offer caching disabled", fake.calls)
+	}
+}
+
+func TestKey(t *testing.T) {
+	payload := []byte(`{"itemIds":["4900900028"]}`)
+	base := Key(paapi5.GetItems, "www.amazon.co.jp", payload)
+	if Key(paapi5.GetItems, "www.amazon.co.jp", payload) != base {
+		t.Error("Key is not stable")
+	}
+	for _, other := range []string{
+		Key(paapi5.GetVariations, "www.amazon.co.jp", payload),
+		Key(paapi5.GetItems, "www.amazon.com", payload),
+		Key(paapi5.GetItems, "www.amazon.co.jp", []byte(`{"itemIds":["4900900029"]}`)),
+	} {
+		if other == base {
+			t.Errorf("Key collision: %s", other)
+		}
+	}
+}
+
+func TestClientResponsePrices(t *testing.T) {
+	testCases := []struct {
+		body string
+		want time.Duration
+	}{
+		{body: `{"itemsResult":{"items":[{"asin":"4900900028","itemInfo":{"title":{"displayValue":"Go"},"tradeInInfo":{"isEligibleForTradeIn":false}}}]}}`, want: 7 * 24 * time.Hour},
+		{body: `{"itemsResult":{"items":[{"asin":"4900900028","itemInfo":{"tradeInInfo":{"isEligibleForTradeIn":true,"price":{"amount":120,"currency":"JPY"}}}}]}}`, want: 2 * time.Hour},
+		{body: `{"searchResult":{"items":[{"asin":"4900900028","offersV2":{"listings":[{"price":{"money":{"amount":2640}}}]}}]}}`, want: 2 * time.Hour},
+		{body: `not json`, want: 2 * time.Hour},
+	}
+	for _, tc := range testCases {
+		fake := &fakeClient{marketplace: "www.amazon.co.jp", body: tc.body}
+		store := &ttlRecorder{LRU: NewLRU(10)}
+		c, err := NewClient(fake, store, WithOfferTTL(2*time.Hour), WithStaticTTL(7*24*time.Hour))
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		q := query.NewGetItems("www.amazon.co.jp", "mytag-20", "").ASINs([]string{"4900900028"}).EnableItemInfo()
+		if _, err := c.RequestContext(context.Background(), q); err != nil {
+			t.Fatalf("RequestContext: %v", err)
+		}
+		if len(store.ttls) != 1 || store.ttls[0] != tc.want {
+			t.Errorf("TTLs of %s = %v, want [%v]", tc.body, store.ttls, tc.want)
+		}
+	}
+}
+
+func TestHasOfferData(t *testing.T) {
+	testCases := []struct {
+		payload string
+		want    bool
+	}{
+		{payload: `{"itemIds":["4900900028"],"resources":["itemInfo.title","images.primary.large"]}`, want: false},
+		{payload: `{"resources":["offersV2.listings.price"]}`, want: true},
+		{payload: `{"resources":["offers.listings.price"]}`, want: true},
+		{payload: `{"resources":["variationSummary.price.lowestPrice"]}`, want: true},
+		{payload: `{"resources":["variationSummary.variationDimension"]}`, want: false},
+		{payload: `{"keywords":"golang","minPrice":1000}`, want: true},
+		{payload: `{"keywords":"golang","deliveryFlags":["Prime"]}`, want: true},
+		{payload: `{"keywords":"golang","sortBy":"Price:LowToHigh"}`, want: true},
+		{payload: `{"keywords":"golang","sortBy":"Relevance"}`, want: false},
+		{payload: `not json`, want: true},
+	}
+	for _, tc := range testCases {
+		if got := HasOfferData([]byte(tc.payload)); got != tc.want {
+			t.Errorf("HasOfferData(%s) = %v, want %v", tc.payload, got, tc.want)
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/goark/errs"
+)
+
+const (
+	fileCacheDirPerm  = 0o700
+	fileCacheFilePerm = 0o600
+	// fileHeaderLen is the size of the expiry (Unix nanoseconds) stored in
+	// front of each value.
+	fileHeaderLen = 8
+)
+
+// FileCache is a Cache storing one file per entry in a directory, so that
+// entries survive restarts and can be shared by processes. Files are
+// written with owner-only permissions and replaced atomically; expired
+// entries are removed when read.
+type FileCache struct {
+	dir string
+	now func() time.Time
+}
+
+var _ Cache = (*FileCache)(nil) //FileCache is compatible with Cache interface
+
+// NewFileCache function returns a FileCache in dir. The directory is
+// created on the first Set.
+func NewFileCache(dir string) *FileCache {
+	return &FileCache{dir: dir, now: time.Now}
+}
+
+// path returns the file of key.
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// Get method returns the value of key unless it is missing, expired or
+// unreadable.
+func (c *FileCache) Get(key string) ([]byte, bool) {
+	b, err := os.ReadFile(filepath.Clean(c.path(key)))
+	if err != nil || len(b) < fileHeaderLen {
+		return nil, false
+	}
+	expiresAt := time.Unix(0, int64(binary.BigEndian.Uint64(b[:fileHeaderLen]))) //nolint:gosec // G115: the header was written from an int64.
+	if !c.now().Before(expiresAt) {
+		_ = c.Delete(key)
+		return nil, false
+	}
+	return b[fileHeaderLen:], true
+}
+
+// Set method stores value under key for ttl. A non-positive ttl removes
+// key. The entry is written to a temporary file and renamed into place so
+// concurrent readers never see a partial entry.
+func (c *FileCache) Set(key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		return c.Delete(key)
+	}
+	if err := os.MkdirAll(c.dir, fileCacheDirPerm); err != nil {
+		return errs.Wrap(err, errs.WithContext("dir", c.dir))
+	}
+	b := make([]byte, fileHeaderLen, fileHeaderLen+len(value))
+	binary.BigEndian.PutUint64(b, uint64(c.now().Add(ttl).UnixNano())) //nolint:gosec // G115: read back as int64 by Get.
+	b = append(b, value...)
+	path := c.path(key)
+	tmp, err := os.CreateTemp(c.dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return errs.Wrap(err, errs.WithContext("dir", c.dir))
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := tmp.Chmod(fileCacheFilePerm); err != nil {
+		_ = tmp.Close()
+		return errs.Wrap(err, errs.WithContext("file", tmp.Name()))
+	}
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return errs.Wrap(err, errs.WithContext("file", tmp.Name()))
+	}
+	if err := tmp.Close(); err != nil {
+		return errs.Wrap(err, errs.WithContext("file", tmp.Name()))
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errs.Wrap(err, errs.WithContext("file", path))
+	}
+	return nil
+}
+
+// Delete method removes key.
+func (c *FileCache) Delete(key string) error {
+	if err := os.Remove(c.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errs.Wrap(err, errs.WithContext("file", c.path(key)))
+	}
+	return nil
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
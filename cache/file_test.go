@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestFileCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "paapi")
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFileCache(dir)
+	c.now = func() time.Time { return now }
+
+	if _, ok := c.Get("k"); ok {
+		t.Error("Get on an empty cache should miss")
+	}
+	if err := c.Set("k", []byte(`{"itemsResult":{}}`), time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	other := NewFileCache(dir)
+	other.now = c.now
+	if v, ok := other.Get("k"); !ok || string(v) != `{"itemsResult":{}}` {
+		t.Errorf("Get from a second FileCache = %q, %v", v, ok)
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(c.path("k"))
+		if err != nil {
+			t.Fatalf("Stat: %v", err)
+		}
+		if perm := info.Mode().Perm(); perm != fileCacheFilePerm {
+			t.Errorf("file mode = %o, want %o", perm, fileCacheFilePerm)
+		}
+	}
+
+	now = now.Add(time.Hour)
+	if _, ok := c.Get("k"); ok {
+		t.Error("k should have expired")
+	}
+	if _, err := os.Stat(c.path("k")); !os.IsNotExist(err) {
+		t.Errorf("expired entry still on disk: %v", err)
+	}
+
+	_ = c.Set("k", []byte("v"), time.Hour)
+	if err := c.Delete("k"); err != nil {
+		t.Errorf("Delete: %v", err)
+	}
+	if err := c.Delete("k"); err != nil {
+		t.Errorf("Delete of a missing key: %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("directory not empty: %v", entries)
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"bytes"
+	"container/list"
+	"sync"
+	"time"
+)
+
+// DefaultLRUSize is the capacity of an LRU created with a non-positive size.
+const DefaultLRUSize = 1024
+
+// LRU is an in-memory Cache holding at most a fixed number of entries and
+// evicting the least recently used one when full.
+type LRU struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // front is the most recently used
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+var _ Cache = (*LRU)(nil) //LRU is compatible with Cache interface
+
+// lruEntry is an element of LRU.order.
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRU function returns an empty LRU holding at most size entries
+// (DefaultLRUSize if size is not positive).
+func NewLRU(size int) *LRU {
+	if size <= 0 {
+		size = DefaultLRUSize
+	}
+	return &LRU{size: size, order: list.New(), entries: map[string]*list.Element{}, now: time.Now}
+}
+
+// Get method returns a copy of the value of key unless it is missing or
+// expired.
+func (c *LRU) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*lruEntry)
+	if !c.now().Before(e.expiresAt) {
+		c.removeLocked(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return bytes.Clone(e.value), true
+}
+
+// Set method stores a copy of value under key for ttl, evicting the least recently
+// used entry if the LRU is full. A non-positive ttl removes key.
+func (c *LRU) Set(key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.removeLocked(el)
+	}
+	if ttl <= 0 {
+		return nil
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: bytes.Clone(value), expiresAt: c.now().Add(ttl)})
+	for c.order.Len() > c.size {
+		c.removeLocked(c.order.Back())
+	}
+	return nil
+}
+
+// Delete method removes key.
+func (c *LRU) Delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.removeLocked(el)
+	}
+	return nil
+}
+
+// Len method returns the number of entries, including expired ones not
+// yet evicted.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRU) removeLocked(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*lruEntry).key)
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
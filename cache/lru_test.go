@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewLRU(2)
+	c.now = func() time.Time { return now }
+
+	_ = c.Set("a", []byte("A"), time.Minute)
+	_ = c.Set("b", []byte("B"), time.Hour)
+	if v, ok := c.Get("a"); !ok || string(v) != "A" {
+		t.Errorf("Get(a) = %q, %v", v, ok)
+	}
+	_ = c.Set("c", []byte("C"), time.Hour) // evicts b, the least recently used
+	if _, ok := c.Get("b"); ok {
+		t.Error("b should have been evicted")
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", c.Len())
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Get("a"); ok {
+		t.Error("a should have expired")
+	}
+	if v, ok := c.Get("c"); !ok || string(v) != "C" {
+		t.Errorf("Get(c) = %q, %v", v, ok)
+	}
+	_ = c.Delete("c")
+	_ = c.Set("d", []byte("D"), 0)
+	if c.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", c.Len())
+	}
+	if NewLRU(0).size != DefaultLRUSize {
+		t.Errorf("NewLRU(0) size = %d, want %d", NewLRU(0).size, DefaultLRUSize)
+	}
+}
+
+func TestLRUCopies(t *testing.T) {
+	c := NewLRU(1)
+	value := []byte("ABC")
+	_ = c.Set("a", value, time.Hour)
+	value[0] = 'X'
+	v, ok := c.Get("a")
+	if !ok || string(v) != "ABC" {
+		t.Fatalf("Get(a) after changing the set slice = %q, %v, want \"ABC\"", v, ok)
+	}
+	v[0] = 'Y'
+	if v, ok := c.Get("a"); !ok || string(v) != "ABC" {
+		t.Errorf("Get(a) after changing a returned slice = %q, %v, want \"ABC\"", v, ok)
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,196 @@
+// Package catalog provides high-level helpers built on top of the
+// Creators API client, the query package and the entity package.
+package catalog
+
+import (
+	"context"
+	"sync"
+
+	"github.com/goark/errs"
+	paapi5 "github.com/goark/pa-api"
+	"github.com/goark/pa-api/entity"
+	"github.com/goark/pa-api/query"
+)
+
+const (
+	// maxItemIDs is the maximum number of item IDs accepted by a single
+	// GetItems request.
+	maxItemIDs = 10
+	// defaultBatchConcurrency is the number of chunks requested in parallel
+	// by default.
+	defaultBatchConcurrency = 1
+)
+
+// batchConfig holds the settings of BatchGetItems.
+type batchConfig struct {
+	concurrency int
+	prepare     []func(*query.GetItems)
+}
+
+// BatchOptFunc type is self-referential function type for BatchGetItems function. (functional options pattern)
+type BatchOptFunc func(*batchConfig)
+
+// WithConcurrency returns a BatchOptFunc that bounds the number of GetItems
+// requests issued in parallel. Values below 1 are ignored.
+func WithConcurrency(n int) BatchOptFunc {
+	return func(c *batchConfig) {
+		if c != nil && n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithGetItemsQuery returns a BatchOptFunc that customises the GetItems query
+// of every chunk, typically to enable resources or set filters. The item IDs
+// of the chunk are already set when f is called.
+func WithGetItemsQuery(f func(*query.GetItems)) BatchOptFunc {
+	return func(c *batchConfig) {
+		if c != nil && f != nil {
+			c.prepare = append(c.prepare, f)
+		}
+	}
+}
+
+// BatchGetItems fetches any number of ASINs through GetItems, splitting them
+// into chunks of at most ten item IDs and requesting the chunks with bounded
+// concurrency (see WithConcurrency). Duplicate ASINs are requested once.
+//
+// The items of all chunks are merged into ItemsResult.Items in the order of
+// the input ASINs, followed by any item whose ASIN was not requested; the
+// per-item Errors of all chunks are merged into Errors. If some chunks fail,
+// the merged result of the successful chunks is returned together with the
+// joined errors of the failed ones.
+func BatchGetItems(ctx context.Context, client paapi5.Client, asins []string, opts ...BatchOptFunc) (*entity.Response, error) {
+	if client == nil {
+		return nil, errs.Wrap(paapi5.ErrNullPointer, errs.WithContext("reason", "nil client"))
+	}
+	cfg := &batchConfig{concurrency: defaultBatchConcurrency}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	ids := uniqueStrings(asins)
+	chunks := chunkStrings(ids, maxItemIDs)
+
+	results := make([]*entity.Response, len(chunks))
+	errList := make([]error, len(chunks))
+	sem := make(chan struct{}, cfg.concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errList[i] = errs.Wrap(ctx.Err(), errs.WithContext("asins", chunk))
+			continue
+		}
+		wg.Add(1)
+		go func(i int, chunk []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errList[i] = getItems(ctx, client, chunk, cfg.prepare)
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	return mergeItems(ids, results), errs.Join(errList...)
+}
+
+// getItems requests a single chunk of item IDs.
+func getItems(ctx context.Context, client paapi5.Client, ids []string, prepare []func(*query.GetItems)) (*entity.Response, error) {
+	q := query.NewGetItems(client.Marketplace(), client.PartnerTag(), client.PartnerType()).ASINs(ids)
+	for _, f := range prepare {
+		f(q)
+	}
+	body, err := client.RequestContext(ctx, q)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("asins", ids))
+	}
+	rsp, err := entity.DecodeResponse(body)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("asins", ids))
+	}
+	return rsp, nil
+}
+
+// mergeItems merges the responses of all chunks, ordering items by ids.
+func mergeItems(ids []string, results []*entity.Response) *entity.Response {
+	merged := &entity.Response{}
+	byASIN := map[string]entity.Item{}
+	extra := []entity.Item{}
+	requested := map[string]bool{}
+	for _, id := range ids {
+		requested[id] = true
+	}
+	for _, rsp := range results {
+		if rsp == nil {
+			continue
+		}
+		merged.Errors = append(merged.Errors, rsp.Errors...)
+		if rsp.ItemsResult == nil {
+			continue
+		}
+		if merged.ItemsResult == nil {
+			merged.ItemsResult = rsp.ItemsResult
+		}
+		for _, item := range rsp.ItemsResult.Items {
+			if _, ok := byASIN[item.ASIN]; !ok && requested[item.ASIN] {
+				byASIN[item.ASIN] = item
+			} else {
+				extra = append(extra, item)
+			}
+		}
+	}
+	if merged.ItemsResult == nil {
+		return merged
+	}
+	items := make([]entity.Item, 0, len(byASIN)+len(extra))
+	for _, id := range ids {
+		if item, ok := byASIN[id]; ok {
+			items = append(items, item)
+		}
+	}
+	merged.ItemsResult.Items = append(items, extra...)
+	return merged
+}
+
+// uniqueStrings returns ss without duplicates and empty strings, keeping the
+// first occurrence of each value.
+func uniqueStrings(ss []string) []string {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(ss))
+	for _, s := range ss {
+		if len(s) == 0 || seen[s] {
+			continue
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+	return out
+}
+
+// chunkStrings splits ss into consecutive chunks of at most size elements.
+func chunkStrings(ss []string, size int) [][]string {
+	chunks := [][]string{}
+	for len(ss) > size {
+		chunks = append(chunks, ss[:size:size])
+		ss = ss[size:]
+	}
+	if len(ss) > 0 {
+		chunks = append(chunks, ss)
+	}
+	return chunks
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
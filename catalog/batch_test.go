@@ -0,0 +1,198 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	paapi5 "github.com/goark/pa-api"
+	"github.com/goark/pa-api/query"
+)
+
+// fakeClient is a paapi5.Client answering requests with handler.
+type fakeClient struct {
+	handler func(op paapi5.Operation, payload map[string]any) ([]byte, error)
+
+	mu       sync.Mutex
+	payloads []map[string]any
+}
+
+var _ paapi5.Client = (*fakeClient)(nil)
+
+func (c *fakeClient) Marketplace() string { return "www.amazon.com" }
+func (c *fakeClient) PartnerTag() string  { return "mytag-20" }
+func (c *fakeClient) PartnerType() string { return "Associates" }
+func (c *fakeClient) Request(q paapi5.Query) ([]byte, error) {
+	return c.RequestContext(context.Background(), q)
+}
+func (c *fakeClient) RequestContext(ctx context.Context, q paapi5.Query) ([]byte, error) {
+	b, err := q.Payload()
+	if err != nil {
+		return nil, err
+	}
+	payload := map[string]any{}
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.payloads = append(c.payloads, payload)
+	c.mu.Unlock()
+	return c.handler(q.Operation(), payload)
+}
+
+// stringsOf returns payload[key] as a string slice.
+func stringsOf(payload map[string]any, key string) []string {
+	ss := []string{}
+	if list, ok := payload[key].([]any); ok {
+		for _, v := range list {
+			ss = append(ss, fmt.Sprint(v))
+		}
+	}
+	return ss
+}
+
+// getItemsHandler answers GetItems with one item per requested ASIN, in
+// reverse order, except for ASINs starting with "X", which are reported as
+// inaccessible.
+func getItemsHandler(op paapi5.Operation, payload map[string]any) ([]byte, error) {
+	if op != paapi5.GetItems {
+		return nil, fmt.Errorf("unexpected operation %v", op)
+	}
+	ids := stringsOf(payload, "itemIds")
+	items := []string{}
+	errList := []string{}
+	for i := len(ids) - 1; i >= 0; i-- {
+		if strings.HasPrefix(ids[i], "X") {
+			errList = append(errList, fmt.Sprintf(`{"code":"ItemNotAccessible","message":"The ItemId %s is not accessible through the Creators API."}`, ids[i]))
+			continue
+		}
+		items = append(items, fmt.Sprintf(`{"asin":%q}`, ids[i]))
+	}
+	body := fmt.Sprintf(`{"itemsResult":{"items":[%s]}`, strings.Join(items, ","))
+	if len(errList) > 0 {
+		body += fmt.Sprintf(`,"errors":[%s]`, strings.Join(errList, ","))
+	}
+	return []byte(body + "}"), nil
+}
+
+func TestBatchGetItems(t *testing.T) {
+	asins := []string{}
+	for i := range 23 {
+		asins = append(asins, fmt.Sprintf("A%02d", i))
+	}
+	asins = append(asins, "X01", "A05") // inaccessible item and duplicate
+
+	c := &fakeClient{handler: getItemsHandler}
+	rsp, err := BatchGetItems(context.Background(), c, asins,
+		WithConcurrency(3),
+		WithGetItemsQuery(func(q *query.GetItems) { q.EnableItemInfo() }),
+	)
+	if err != nil {
+		t.Fatalf("BatchGetItems: %+v", err)
+	}
+	if got, want := len(c.payloads), 3; got != want {
+		t.Errorf("requests = %d, want %d", got, want)
+	}
+	for _, p := range c.payloads {
+		if n := len(stringsOf(p, "itemIds")); n > maxItemIDs {
+			t.Errorf("request with %d item IDs exceeds the limit of %d", n, maxItemIDs)
+		}
+		if got := stringsOf(p, "resources"); len(got) == 0 {
+			t.Errorf("request without resources: %v", p)
+		}
+	}
+	if rsp.ItemsResult == nil {
+		t.Fatal("ItemsResult is nil")
+	}
+	if got, want := len(rsp.ItemsResult.Items), 23; got != want {
+		t.Fatalf("len(Items) = %d, want %d", got, want)
+	}
+	for i, item := range rsp.ItemsResult.Items {
+		if want := asins[i]; item.ASIN != want {
+			t.Errorf("Items[%d].ASIN = %q, want %q", i, item.ASIN, want)
+		}
+	}
+	if got, want := len(rsp.Errors), 1; got != want {
+		t.Fatalf("len(Errors) = %d, want %d", got, want)
+	}
+	if got, want := rsp.Errors[0].Code, "ItemNotAccessible"; got != want {
+		t.Errorf("Errors[0].Code = %q, want %q", got, want)
+	}
+}
+
+func TestBatchGetItemsBoundedConcurrency(t *testing.T) {
+	var running, peak int32
+	c := &fakeClient{handler: func(op paapi5.Operation, payload map[string]any) ([]byte, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return getItemsHandler(op, payload)
+	}}
+	asins := []string{}
+	for i := range 60 {
+		asins = append(asins, fmt.Sprintf("A%02d", i))
+	}
+	if _, err := BatchGetItems(context.Background(), c, asins, WithConcurrency(2)); err != nil {
+		t.Fatalf("BatchGetItems: %+v", err)
+	}
+	if got := atomic.LoadInt32(&peak); got > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", got)
+	}
+}
+
+func TestBatchGetItemsPartialFailure(t *testing.T) {
+	wantErr := errors.New("chunk failed")
+	c := &fakeClient{handler: func(op paapi5.Operation, payload map[string]any) ([]byte, error) {
+		if ids := stringsOf(payload, "itemIds"); len(ids) > 0 && ids[0] == "A10" {
+			return nil, wantErr
+		}
+		return getItemsHandler(op, payload)
+	}}
+	asins := []string{}
+	for i := range 15 {
+		asins = append(asins, fmt.Sprintf("A%02d", i))
+	}
+	rsp, err := BatchGetItems(context.Background(), c, asins)
+	if !errors.Is(err, wantErr) {
+		t.Errorf("error = %v, want %v", err, wantErr)
+	}
+	if rsp == nil || rsp.ItemsResult == nil {
+		t.Fatal("partial result is missing")
+	}
+	if got, want := len(rsp.ItemsResult.Items), 10; got != want {
+		t.Errorf("len(Items) = %d, want %d", got, want)
+	}
+}
+
+func TestBatchGetItemsNilClient(t *testing.T) {
+	if _, err := BatchGetItems(context.Background(), nil, []string{"A1"}); !errors.Is(err, paapi5.ErrNullPointer) {
+		t.Errorf("error = %v, want ErrNullPointer", err)
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
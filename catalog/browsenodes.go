@@ -0,0 +1,212 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/goark/errs"
+	paapi5 "github.com/goark/pa-api"
+	"github.com/goark/pa-api/entity"
+	"github.com/goark/pa-api/query"
+)
+
+// maxBrowseNodeIDs is the maximum number of browse node IDs accepted by a
+// single GetBrowseNodes request.
+const maxBrowseNodeIDs = 10
+
+// BrowseNode is a node of a browse node tree built by CrawlBrowseNodes.
+// Children is nil for nodes that were not expanded, either because of the
+// depth limit or because the node was already expanded elsewhere in the tree.
+// JSON keys match those of entity.BrowseNode.
+type BrowseNode struct {
+	Id              string        `json:"id"`
+	DisplayName     string        `json:"displayName,omitempty"`
+	ContextFreeName string        `json:"contextFreeName,omitempty"`
+	IsRoot          bool          `json:"isRoot"`
+	Children        []*BrowseNode `json:"children,omitempty"`
+}
+
+// BrowseNodeTree is an in-memory browse node taxonomy built by CrawlBrowseNodes.
+type BrowseNodeTree struct {
+	Roots []*BrowseNode `json:"roots"`
+}
+
+// JSON returns JSON data from BrowseNodeTree instance
+func (t *BrowseNodeTree) JSON() ([]byte, error) {
+	b, err := json.Marshal(t)
+	return b, errs.Wrap(err)
+}
+
+// Stringer
+func (t *BrowseNodeTree) String() string {
+	b, err := t.JSON()
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// crawlConfig holds the settings of CrawlBrowseNodes.
+type crawlConfig struct {
+	maxDepth     int
+	quotaReserve int
+	prepare      []func(*query.GetBrowseNodes)
+}
+
+// CrawlOptFunc type is self-referential function type for CrawlBrowseNodes function. (functional options pattern)
+type CrawlOptFunc func(*crawlConfig)
+
+// WithMaxDepth returns a CrawlOptFunc that limits the crawl to depth levels
+// below the roots: nodes at that depth are listed as children of their parent
+// but not expanded. WithMaxDepth(0) fetches the roots only. Negative values
+// are ignored; by default the crawl is not limited.
+func WithMaxDepth(depth int) CrawlOptFunc {
+	return func(c *crawlConfig) {
+		if c != nil && depth >= 0 {
+			c.maxDepth = depth
+		}
+	}
+}
+
+// WithQuotaReserve returns a CrawlOptFunc that stops the crawl before it uses
+// the last n requests of the daily quota of the client (see
+// paapi5.WithRateLimit), leaving them for other work. It has no effect on
+// clients without a daily quota.
+func WithQuotaReserve(n int) CrawlOptFunc {
+	return func(c *crawlConfig) {
+		if c != nil && n > 0 {
+			c.quotaReserve = n
+		}
+	}
+}
+
+// WithGetBrowseNodesQuery returns a CrawlOptFunc that customises every
+// GetBrowseNodes query, typically to set LanguagesOfPreference. The browse
+// node IDs are already set when f is called.
+func WithGetBrowseNodesQuery(f func(*query.GetBrowseNodes)) CrawlOptFunc {
+	return func(c *crawlConfig) {
+		if c != nil && f != nil {
+			c.prepare = append(c.prepare, f)
+		}
+	}
+}
+
+// CrawlBrowseNodes builds the browse node tree below rootIDs. It walks the
+// tree breadth-first, requesting the nodes of each level through
+// GetBrowseNodes in batches of at most ten browse node IDs, and expands every
+// browse node ID at most once. Requests go through the client, so its rate
+// limit applies; see also WithQuotaReserve and WithMaxDepth.
+//
+// Browse node IDs reported in the Errors of a response are left as nodes
+// holding only their Id, and the errors, each wrapping an *entity.ItemError,
+// are joined into the returned error.
+// If a request fails or the context is done, the crawl stops and the tree
+// built so far is returned together with the error.
+func CrawlBrowseNodes(ctx context.Context, client paapi5.Client, rootIDs []string, opts ...CrawlOptFunc) (*BrowseNodeTree, error) {
+	if client == nil {
+		return nil, errs.Wrap(paapi5.ErrNullPointer, errs.WithContext("reason", "nil client"))
+	}
+	cfg := &crawlConfig{maxDepth: -1}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	tree := &BrowseNodeTree{}
+	level := uniqueStrings(rootIDs)
+	pending := map[string][]*BrowseNode{}
+	for _, id := range level {
+		node := &BrowseNode{Id: id}
+		tree.Roots = append(tree.Roots, node)
+		pending[id] = append(pending[id], node)
+	}
+	expanded := map[string]bool{}
+	errList := []error{}
+	for depth := 0; len(level) > 0; depth++ {
+		for _, id := range level {
+			expanded[id] = true
+		}
+		expand := cfg.maxDepth < 0 || depth < cfg.maxDepth
+		next := []string{}
+		nextPending := map[string][]*BrowseNode{}
+		for _, chunk := range chunkStrings(level, maxBrowseNodeIDs) {
+			if err := ctx.Err(); err != nil {
+				return tree, errs.Join(append(errList, errs.Wrap(err, errs.WithContext("browseNodeIds", chunk)))...)
+			}
+			if remaining, ok := paapi5.RemainingDailyQuota(client); ok && remaining <= cfg.quotaReserve {
+				err := errs.Wrap(paapi5.ErrTooManyRequests, errs.WithContext("reason", "daily quota reserve reached"), errs.WithContext("remaining", remaining), errs.WithContext("browseNodeIds", chunk))
+				return tree, errs.Join(append(errList, err)...)
+			}
+			rsp, err := getBrowseNodes(ctx, client, chunk, cfg.prepare)
+			if err != nil {
+				return tree, errs.Join(append(errList, err)...)
+			}
+			for _, e := range rsp.Errors {
+				errList = append(errList, errs.Wrap(paapi5.ErrNoData, errs.WithCause(e.ItemError())))
+			}
+			if rsp.BrowseNodesResult == nil {
+				continue
+			}
+			for _, bn := range rsp.BrowseNodesResult.BrowseNodes {
+				if bn == nil {
+					continue
+				}
+				for _, node := range pending[bn.Id] {
+					node.DisplayName = bn.DisplayName
+					node.ContextFreeName = bn.ContextFreeName
+					node.IsRoot = bn.IsRoot
+					node.Children = make([]*BrowseNode, 0, len(bn.Children))
+					for _, c := range bn.Children {
+						if c == nil {
+							continue
+						}
+						child := &BrowseNode{Id: c.Id, DisplayName: c.DisplayName, ContextFreeName: c.ContextFreeName}
+						node.Children = append(node.Children, child)
+						if !expand || expanded[c.Id] {
+							continue
+						}
+						if _, ok := nextPending[c.Id]; !ok {
+							next = append(next, c.Id)
+						}
+						nextPending[c.Id] = append(nextPending[c.Id], child)
+					}
+				}
+			}
+		}
+		level, pending = next, nextPending
+	}
+	return tree, errs.Join(errList...)
+}
+
+// getBrowseNodes requests a single batch of browse node IDs.
+func getBrowseNodes(ctx context.Context, client paapi5.Client, ids []string, prepare []func(*query.GetBrowseNodes)) (*entity.Response, error) {
+	q := query.NewGetBrowseNodes(client.Marketplace(), client.PartnerTag(), client.PartnerType()).
+		BrowseNodeIds(ids).
+		EnableBrowseNodes()
+	for _, f := range prepare {
+		f(q)
+	}
+	body, err := client.RequestContext(ctx, q)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("browseNodeIds", ids))
+	}
+	rsp, err := entity.DecodeResponse(body)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("browseNodeIds", ids))
+	}
+	return rsp, nil
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
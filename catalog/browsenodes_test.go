@@ -0,0 +1,206 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	paapi5 "github.com/goark/pa-api"
+	"github.com/goark/pa-api/entity"
+)
+
+// taxonomy maps a browse node ID to its children; "3" is also a child of "4".
+var taxonomy = map[string][]string{
+	"1":  {"2", "3", "4"},
+	"2":  {"21", "22"},
+	"3":  {},
+	"4":  {"41", "3"},
+	"21": {},
+	"22": {},
+	"41": {"411"},
+}
+
+// browseNodesHandler answers GetBrowseNodes from taxonomy; unknown IDs are
+// reported in Errors.
+func browseNodesHandler(op paapi5.Operation, payload map[string]any) ([]byte, error) {
+	if op != paapi5.GetBrowseNodes {
+		return nil, fmt.Errorf("unexpected operation %v", op)
+	}
+	nodes := []string{}
+	errList := []string{}
+	for _, id := range stringsOf(payload, "browseNodeIds") {
+		children, ok := taxonomy[id]
+		if !ok {
+			errList = append(errList, fmt.Sprintf(`{"code":"InvalidParameterValue","message":"The BrowseNodeId %s provided in the request is invalid."}`, id))
+			continue
+		}
+		cs := []string{}
+		for _, c := range children {
+			cs = append(cs, fmt.Sprintf(`{"id":%q,"displayName":"Node %s"}`, c, c))
+		}
+		nodes = append(nodes, fmt.Sprintf(`{"id":%q,"displayName":"Node %s","isRoot":%v,"children":[%s]}`, id, id, id == "1", strings.Join(cs, ",")))
+	}
+	body := fmt.Sprintf(`{"browseNodesResult":{"browseNodes":[%s]}`, strings.Join(nodes, ","))
+	if len(errList) > 0 {
+		body += fmt.Sprintf(`,"errors":[%s]`, strings.Join(errList, ","))
+	}
+	return []byte(body + "}"), nil
+}
+
+// shape renders the IDs of a tree as "id(child,child)".
+func shape(nodes []*BrowseNode) string {
+	ss := []string{}
+	for _, n := range nodes {
+		s := n.Id
+		if n.Children != nil {
+			s += "(" + shape(n.Children) + ")"
+		}
+		ss = append(ss, s)
+	}
+	return strings.Join(ss, ",")
+}
+
+func TestCrawlBrowseNodes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     []CrawlOptFunc
+		shape    string
+		requests [][]string
+	}{
+		{name: "unlimited", shape: "1(2(21(),22()),3(),4(41(411),3))", requests: [][]string{{"1"}, {"2", "3", "4"}, {"21", "22", "41"}, {"411"}}},
+		{name: "depth 1", opts: []CrawlOptFunc{WithMaxDepth(1)}, shape: "1(2(21,22),3(),4(41,3))", requests: [][]string{{"1"}, {"2", "3", "4"}}},
+		{name: "depth 0", opts: []CrawlOptFunc{WithMaxDepth(0)}, shape: "1(2,3,4)", requests: [][]string{{"1"}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &fakeClient{handler: browseNodesHandler}
+			tree, err := CrawlBrowseNodes(context.Background(), c, []string{"1", "1"}, tc.opts...)
+			if tc.name == "unlimited" {
+				var ie *entity.ItemError
+				if !errors.Is(err, paapi5.ErrNoData) || !errors.As(err, &ie) || ie.BrowseNodeID != "411" {
+					t.Errorf("CrawlBrowseNodes() error = %v, want the error of the unknown node 411", err)
+				}
+			} else if err != nil {
+				t.Errorf("CrawlBrowseNodes() error = %v, want nil", err)
+			}
+			if got := shape(tree.Roots); got != tc.shape {
+				t.Errorf("tree = %s, want %s", got, tc.shape)
+			}
+			requests := [][]string{}
+			for _, p := range c.payloads {
+				requests = append(requests, stringsOf(p, "browseNodeIds"))
+			}
+			if !reflect.DeepEqual(requests, tc.requests) {
+				t.Errorf("requests = %v, want %v", requests, tc.requests)
+			}
+		})
+	}
+}
+
+func TestCrawlBrowseNodesBatches(t *testing.T) {
+	ids := []string{}
+	for i := range 25 {
+		ids = append(ids, fmt.Sprintf("90%d", i))
+	}
+	c := &fakeClient{handler: browseNodesHandler}
+	tree, err := CrawlBrowseNodes(context.Background(), c, ids)
+	if !errors.Is(err, paapi5.ErrNoData) {
+		t.Errorf("CrawlBrowseNodes() error = %v, want %v", err, paapi5.ErrNoData)
+	}
+	if got := len(tree.Roots); got != 25 {
+		t.Errorf("roots = %d, want 25", got)
+	}
+	sizes := []int{}
+	for _, p := range c.payloads {
+		sizes = append(sizes, len(stringsOf(p, "browseNodeIds")))
+	}
+	if want := []int{10, 10, 5}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("batch sizes = %v, want %v", sizes, want)
+	}
+}
+
+// quotaClient is a fakeClient with a daily quota of remaining requests.
+type quotaClient struct {
+	*fakeClient
+	remaining int
+}
+
+func (c *quotaClient) RequestContext(ctx context.Context, q paapi5.Query) ([]byte, error) {
+	c.remaining--
+	return c.fakeClient.RequestContext(ctx, q)
+}
+
+func (c *quotaClient) RemainingDailyQuota() (int, bool) { return c.remaining, true }
+
+func TestCrawlBrowseNodesQuotaReserve(t *testing.T) {
+	c := &quotaClient{fakeClient: &fakeClient{handler: browseNodesHandler}, remaining: 4}
+	tree, err := CrawlBrowseNodes(context.Background(), c, []string{"1"}, WithQuotaReserve(2))
+	if !errors.Is(err, paapi5.ErrTooManyRequests) {
+		t.Fatalf("CrawlBrowseNodes() error = %v, want %v", err, paapi5.ErrTooManyRequests)
+	}
+	if got, want := shape(tree.Roots), "1(2(21,22),3(),4(41,3))"; got != want {
+		t.Errorf("tree = %s, want %s", got, want)
+	}
+	if got := len(c.payloads); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+func TestCrawlBrowseNodesError(t *testing.T) {
+	failure := errors.New("boom")
+	c := &fakeClient{handler: func(op paapi5.Operation, payload map[string]any) ([]byte, error) {
+		if len(stringsOf(payload, "browseNodeIds")) > 1 {
+			return nil, failure
+		}
+		return browseNodesHandler(op, payload)
+	}}
+	tree, err := CrawlBrowseNodes(context.Background(), c, []string{"1"})
+	if !errors.Is(err, failure) {
+		t.Fatalf("CrawlBrowseNodes() error = %v, want %v", err, failure)
+	}
+	if got, want := shape(tree.Roots), "1(2,3,4)"; got != want {
+		t.Errorf("tree = %s, want %s", got, want)
+	}
+	if _, err := CrawlBrowseNodes(context.Background(), nil, []string{"1"}); !errors.Is(err, paapi5.ErrNullPointer) {
+		t.Errorf("CrawlBrowseNodes(nil) error = %v, want %v", err, paapi5.ErrNullPointer)
+	}
+}
+
+func TestBrowseNodeTreeJSON(t *testing.T) {
+	c := &fakeClient{handler: browseNodesHandler}
+	tree, err := CrawlBrowseNodes(context.Background(), c, []string{"1"}, WithMaxDepth(0))
+	if err != nil {
+		t.Fatalf("CrawlBrowseNodes() error = %+v", err)
+	}
+	b, err := tree.JSON()
+	if err != nil {
+		t.Fatalf("JSON() error = %+v", err)
+	}
+	want := `{"roots":[{"id":"1","displayName":"Node 1","isRoot":true,"children":[{"id":"2","displayName":"Node 2","isRoot":false},{"id":"3","displayName":"Node 3","isRoot":false},{"id":"4","displayName":"Node 4","isRoot":false}]}]}`
+	if got := string(b); got != want {
+		t.Errorf("JSON() = %s, want %s", got, want)
+	}
+	decoded := &BrowseNodeTree{}
+	if err := json.Unmarshal(b, decoded); err != nil || shape(decoded.Roots) != "1(2,3,4)" {
+		t.Errorf("round trip = %s, %v", shape(decoded.Roots), err)
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
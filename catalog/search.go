@@ -0,0 +1,105 @@
+package catalog
+
+import (
+	"context"
+	"iter"
+
+	"github.com/goark/errs"
+	paapi5 "github.com/goark/pa-api"
+	"github.com/goark/pa-api/entity"
+	"github.com/goark/pa-api/query"
+)
+
+const (
+	// maxItemPage is the last page the Creators API serves for SearchItems.
+	maxItemPage = 10
+	// defaultItemCount is the page size the Creators API uses when
+	// ItemCount is not set.
+	defaultItemCount = 10
+)
+
+// SearchAllItems returns an iterator over all results of a SearchItems
+// query. Starting from the query's ItemPage (1 if unset), it requests page
+// after page, yielding the items of each, until TotalResultCount items have
+// been seen, a page comes back empty, or the API's page limit of ten is
+// reached. The supplied query is not modified.
+//
+// On failure, including cancellation of ctx, the iterator yields the error
+// with a zero Item and stops.
+func SearchAllItems(ctx context.Context, client paapi5.Client, q *query.SearchItems) iter.Seq2[entity.Item, error] {
+	return func(yield func(entity.Item, error) bool) {
+		if client == nil || q == nil {
+			yield(entity.Item{}, errs.Wrap(paapi5.ErrNullPointer, errs.WithContext("reason", "nil client or query")))
+			return
+		}
+		start := q.ItemPage
+		if start < 1 {
+			start = 1
+		}
+		for page := start; page <= maxItemPage; page++ {
+			if err := ctx.Err(); err != nil {
+				yield(entity.Item{}, errs.Wrap(err, errs.WithContext("page", page)))
+				return
+			}
+			// A shallow copy is enough: Request only rewrites the ItemPage
+			// field of the copy.
+			pq := *q
+			pq.Request(query.ItemPage, page)
+			rsp, err := search(ctx, client, &pq)
+			if err != nil {
+				yield(entity.Item{}, errs.Wrap(err, errs.WithContext("page", page)))
+				return
+			}
+			if rsp.SearchResult == nil || len(rsp.SearchResult.Items) == 0 {
+				return
+			}
+			for _, item := range rsp.SearchResult.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			// Results up to and including this page, counted from the
+			// first result of the search.
+			upTo := (page-1)*pageSize(q) + len(rsp.SearchResult.Items)
+			if total := rsp.SearchResult.TotalResultCount; total > 0 && upTo >= total {
+				return
+			}
+		}
+	}
+}
+
+// pageSize returns the number of items per page requested by q.
+func pageSize(q *query.SearchItems) int {
+	if q.ItemCount > 0 {
+		return q.ItemCount
+	}
+	return defaultItemCount
+}
+
+// search requests a single page of q.
+func search(ctx context.Context, client paapi5.Client, q *query.SearchItems) (*entity.Response, error) {
+	body, err := client.RequestContext(ctx, q)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	rsp, err := entity.DecodeResponse(body)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return rsp, nil
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
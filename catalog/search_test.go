@@ -0,0 +1,148 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	paapi5 "github.com/goark/pa-api"
+	"github.com/goark/pa-api/query"
+)
+
+// searchHandler returns a handler serving total search results in pages of
+// the requested itemCount (10 by default).
+func searchHandler(total int) func(paapi5.Operation, map[string]any) ([]byte, error) {
+	return func(op paapi5.Operation, payload map[string]any) ([]byte, error) {
+		if op != paapi5.SearchItems {
+			return nil, fmt.Errorf("unexpected operation %v", op)
+		}
+		page, size := 1, 10
+		if v, ok := payload["itemPage"].(float64); ok {
+			page = int(v)
+		}
+		if v, ok := payload["itemCount"].(float64); ok {
+			size = int(v)
+		}
+		items := []string{}
+		for i := (page - 1) * size; i < page*size && i < total; i++ {
+			items = append(items, fmt.Sprintf(`{"asin":"S%03d"}`, i))
+		}
+		return []byte(fmt.Sprintf(`{"searchResult":{"items":[%s],"totalResultCount":%d}}`, strings.Join(items, ","), total)), nil
+	}
+}
+
+func TestSearchAllItems(t *testing.T) {
+	testCases := []struct {
+		total    int
+		count    int
+		page     int
+		items    int
+		requests int
+	}{
+		{total: 23, items: 23, requests: 3},
+		{total: 20, items: 20, requests: 2},
+		{total: 500, items: 100, requests: 10},
+		{total: 500, count: 5, items: 50, requests: 10},
+		{total: 23, page: 2, items: 13, requests: 2},
+		{total: 0, items: 0, requests: 1},
+	}
+	for _, tc := range testCases {
+		c := &fakeClient{handler: searchHandler(tc.total)}
+		q := query.NewSearchItems("", "mytag-20", "").Search(query.Keywords, "math")
+		if tc.count > 0 {
+			q.Request(query.ItemCount, tc.count)
+		}
+		if tc.page > 0 {
+			q.Request(query.ItemPage, tc.page)
+		}
+		n := 0
+		for item, err := range SearchAllItems(context.Background(), c, q) {
+			if err != nil {
+				t.Fatalf("SearchAllItems(total=%d): %+v", tc.total, err)
+			}
+			if item.ASIN == "" {
+				t.Errorf("SearchAllItems(total=%d) yielded an empty item", tc.total)
+			}
+			n++
+		}
+		if n != tc.items {
+			t.Errorf("SearchAllItems(total=%d, count=%d, page=%d) yielded %d items, want %d", tc.total, tc.count, tc.page, n, tc.items)
+		}
+		if got := len(c.payloads); got != tc.requests {
+			t.Errorf("SearchAllItems(total=%d, count=%d, page=%d) issued %d requests, want %d", tc.total, tc.count, tc.page, got, tc.requests)
+		}
+		if got := q.ItemPage; got != tc.page {
+			t.Errorf("query ItemPage modified to %d, want %d", got, tc.page)
+		}
+	}
+}
+
+func TestSearchAllItemsStopsEarly(t *testing.T) {
+	c := &fakeClient{handler: searchHandler(100)}
+	q := query.NewSearchItems("", "mytag-20", "").Search(query.Keywords, "math")
+	n := 0
+	for _, err := range SearchAllItems(context.Background(), c, q) {
+		if err != nil {
+			t.Fatalf("SearchAllItems: %+v", err)
+		}
+		if n++; n == 5 {
+			break
+		}
+	}
+	if got, want := len(c.payloads), 1; got != want {
+		t.Errorf("issued %d requests, want %d", got, want)
+	}
+}
+
+func TestSearchAllItemsErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := &fakeClient{handler: searchHandler(100)}
+	q := query.NewSearchItems("", "mytag-20", "").Search(query.Keywords, "math")
+	var gotErr error
+	n := 0
+	for _, err := range SearchAllItems(ctx, c, q) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		if n++; n == 10 {
+			cancel()
+		}
+	}
+	if !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", gotErr)
+	}
+
+	wantErr := errors.New("boom")
+	c = &fakeClient{handler: func(paapi5.Operation, map[string]any) ([]byte, error) { return nil, wantErr }}
+	for _, err := range SearchAllItems(context.Background(), c, q) {
+		gotErr = err
+	}
+	if !errors.Is(gotErr, wantErr) {
+		t.Errorf("error = %v, want %v", gotErr, wantErr)
+	}
+	for _, err := range SearchAllItems(context.Background(), nil, q) {
+		gotErr = err
+	}
+	if !errors.Is(gotErr, paapi5.ErrNullPointer) {
+		t.Errorf("error = %v, want ErrNullPointer", gotErr)
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,161 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/goark/errs"
+	paapi5 "github.com/goark/pa-api"
+	"github.com/goark/pa-api/entity"
+	"github.com/goark/pa-api/query"
+)
+
+// variationsConfig holds the settings of GetAllVariations.
+type variationsConfig struct {
+	prepare []func(*query.GetVariations)
+}
+
+// VariationsOptFunc type is self-referential function type for GetAllVariations function. (functional options pattern)
+type VariationsOptFunc func(*variationsConfig)
+
+// WithGetVariationsQuery returns a VariationsOptFunc that customises the
+// GetVariations query of every page, typically to enable resources or set
+// filters such as VariationCount. The ASIN and VariationPage are already set
+// when f is called.
+func WithGetVariationsQuery(f func(*query.GetVariations)) VariationsOptFunc {
+	return func(c *variationsConfig) {
+		if c != nil && f != nil {
+			c.prepare = append(c.prepare, f)
+		}
+	}
+}
+
+// GetAllVariations fetches every GetVariations page for asin and merges them
+// into one Response. VariationsResult.Items holds the items of all pages in
+// page order, and VariationsResult.VariationSummary combines the summaries of
+// all pages: the highest and lowest prices over all pages, and the union of
+// the values of every variation dimension (the full VariationDimensions
+// matrix). The VariationSummary resource is always requested, since its
+// PageCount drives the traversal. Errors of all pages are merged into Errors.
+func GetAllVariations(ctx context.Context, client paapi5.Client, asin string, opts ...VariationsOptFunc) (*entity.Response, error) {
+	if client == nil {
+		return nil, errs.Wrap(paapi5.ErrNullPointer, errs.WithContext("reason", "nil client"))
+	}
+	cfg := &variationsConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	merged, err := getVariations(ctx, client, asin, 1, cfg.prepare)
+	if err != nil {
+		return nil, err
+	}
+	if merged.VariationsResult == nil || merged.VariationsResult.VariationSummary == nil {
+		return merged, nil
+	}
+	pageCount := merged.VariationsResult.VariationSummary.PageCount
+	for page := 2; page <= pageCount; page++ {
+		if err := ctx.Err(); err != nil {
+			return merged, errs.Wrap(err, errs.WithContext("asin", asin), errs.WithContext("page", page))
+		}
+		rsp, err := getVariations(ctx, client, asin, page, cfg.prepare)
+		if err != nil {
+			return merged, err
+		}
+		mergeVariations(merged, rsp)
+	}
+	return merged, nil
+}
+
+// getVariations requests a single page of variations of asin.
+func getVariations(ctx context.Context, client paapi5.Client, asin string, page int, prepare []func(*query.GetVariations)) (*entity.Response, error) {
+	q := query.NewGetVariations(client.Marketplace(), client.PartnerTag(), client.PartnerType()).
+		ASIN(asin).
+		Request(query.VariationPage, page).
+		EnableVariationSummary()
+	for _, f := range prepare {
+		f(q)
+	}
+	body, err := client.RequestContext(ctx, q)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("asin", asin), errs.WithContext("page", page))
+	}
+	rsp, err := entity.DecodeResponse(body)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("asin", asin), errs.WithContext("page", page))
+	}
+	return rsp, nil
+}
+
+// mergeVariations merges the page rsp into merged.
+func mergeVariations(merged, rsp *entity.Response) {
+	merged.Errors = append(merged.Errors, rsp.Errors...)
+	if rsp.VariationsResult == nil {
+		return
+	}
+	dst := merged.VariationsResult
+	dst.Items = append(dst.Items, rsp.VariationsResult.Items...)
+	src := rsp.VariationsResult.VariationSummary
+	if src == nil {
+		return
+	}
+	sum := dst.VariationSummary
+	if src.VariationCount > sum.VariationCount {
+		sum.VariationCount = src.VariationCount
+	}
+	if src.Price != nil {
+		if sum.Price == nil {
+			sum.Price = src.Price
+		} else {
+			if src.Price.HighestPrice != nil && (sum.Price.HighestPrice == nil || src.Price.HighestPrice.Amount > sum.Price.HighestPrice.Amount) {
+				sum.Price.HighestPrice = src.Price.HighestPrice
+			}
+			if src.Price.LowestPrice != nil && (sum.Price.LowestPrice == nil || src.Price.LowestPrice.Amount < sum.Price.LowestPrice.Amount) {
+				sum.Price.LowestPrice = src.Price.LowestPrice
+			}
+		}
+	}
+	sum.VariationDimensions = mergeDimensions(sum.VariationDimensions, src.VariationDimensions)
+}
+
+// mergeDimensions returns the union of two VariationDimension lists. Values
+// of dimensions with the same Name are merged, keeping first-seen order.
+func mergeDimensions(dst, src []entity.VariationDimension) []entity.VariationDimension {
+	index := map[string]int{}
+	for i, d := range dst {
+		index[d.Name] = i
+	}
+	for _, d := range src {
+		i, ok := index[d.Name]
+		if !ok {
+			index[d.Name] = len(dst)
+			d.Values = append([]string(nil), d.Values...)
+			dst = append(dst, d)
+			continue
+		}
+		seen := map[string]bool{}
+		for _, v := range dst[i].Values {
+			seen[v] = true
+		}
+		for _, v := range d.Values {
+			if !seen[v] {
+				seen[v] = true
+				dst[i].Values = append(dst[i].Values, v)
+			}
+		}
+	}
+	return dst
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
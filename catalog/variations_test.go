@@ -0,0 +1,140 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	paapi5 "github.com/goark/pa-api"
+	"github.com/goark/pa-api/query"
+)
+
+// variationsHandler serves three pages of variations; each page reports its
+// own price range and the dimension values of its own items.
+func variationsHandler(op paapi5.Operation, payload map[string]any) ([]byte, error) {
+	if op != paapi5.GetVariations {
+		return nil, fmt.Errorf("unexpected operation %v", op)
+	}
+	page, _ := payload["variationPage"].(float64)
+	if page == 0 {
+		page = 1
+	}
+	pages := map[int]string{
+		1: `{"variationsResult":{"items":[{"asin":"C1"},{"asin":"C2"}],"variationSummary":{"pageCount":3,"variationCount":5,
+			"price":{"highestPrice":{"amount":20},"lowestPrice":{"amount":10}},
+			"variationDimensions":[{"name":"color","values":["Red","Blue"]},{"name":"size","values":["S"]}]}}}`,
+		2: `{"variationsResult":{"items":[{"asin":"C3"},{"asin":"C4"}],"variationSummary":{"pageCount":3,"variationCount":5,
+			"price":{"highestPrice":{"amount":35},"lowestPrice":{"amount":12}},
+			"variationDimensions":[{"name":"color","values":["Blue","Green"]},{"name":"size","values":["M"]}]}}}`,
+		3: `{"variationsResult":{"items":[{"asin":"C5"}],"variationSummary":{"pageCount":3,"variationCount":5,
+			"price":{"highestPrice":{"amount":15},"lowestPrice":{"amount":8}},
+			"variationDimensions":[{"name":"pattern","values":["Plain"]}]}},
+			"errors":[{"code":"Partial","message":"partial page"}]}`,
+	}
+	body, ok := pages[int(page)]
+	if !ok {
+		return nil, fmt.Errorf("unexpected page %v", page)
+	}
+	return []byte(body), nil
+}
+
+func TestGetAllVariations(t *testing.T) {
+	c := &fakeClient{handler: variationsHandler}
+	rsp, err := GetAllVariations(context.Background(), c, "PARENT", WithGetVariationsQuery(func(q *query.GetVariations) {
+		q.EnableItemInfo()
+	}))
+	if err != nil {
+		t.Fatalf("GetAllVariations() error = %+v", err)
+	}
+	if got, want := len(c.payloads), 3; got != want {
+		t.Fatalf("requests = %d, want %d", got, want)
+	}
+	for _, p := range c.payloads {
+		if got := p["asin"]; got != "PARENT" {
+			t.Errorf("asin = %v, want PARENT", got)
+		}
+		resources := stringsOf(p, "resources")
+		if !contains(resources, "variationSummary.variationDimension") || !contains(resources, "itemInfo.title") {
+			t.Errorf("resources = %v, want variation summary and item info", resources)
+		}
+	}
+	asins := []string{}
+	for _, item := range rsp.VariationsResult.Items {
+		asins = append(asins, item.ASIN)
+	}
+	if want := []string{"C1", "C2", "C3", "C4", "C5"}; !reflect.DeepEqual(asins, want) {
+		t.Errorf("items = %v, want %v", asins, want)
+	}
+	sum := rsp.VariationsResult.VariationSummary
+	if sum.PageCount != 3 || sum.VariationCount != 5 {
+		t.Errorf("PageCount, VariationCount = %d, %d, want 3, 5", sum.PageCount, sum.VariationCount)
+	}
+	if sum.Price.HighestPrice.Amount != 35 || sum.Price.LowestPrice.Amount != 8 {
+		t.Errorf("price range = %v - %v, want 8 - 35", sum.Price.LowestPrice.Amount, sum.Price.HighestPrice.Amount)
+	}
+	dims := map[string][]string{}
+	names := []string{}
+	for _, d := range sum.VariationDimensions {
+		names = append(names, d.Name)
+		dims[d.Name] = d.Values
+	}
+	if want := []string{"color", "size", "pattern"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("dimensions = %v, want %v", names, want)
+	}
+	if want := []string{"Red", "Blue", "Green"}; !reflect.DeepEqual(dims["color"], want) {
+		t.Errorf("color = %v, want %v", dims["color"], want)
+	}
+	if want := []string{"S", "M"}; !reflect.DeepEqual(dims["size"], want) {
+		t.Errorf("size = %v, want %v", dims["size"], want)
+	}
+	if len(rsp.Errors) != 1 || rsp.Errors[0].Code != "Partial" {
+		t.Errorf("Errors = %+v, want the error of page 3", rsp.Errors)
+	}
+}
+
+func TestGetAllVariationsError(t *testing.T) {
+	failure := errors.New("boom")
+	c := &fakeClient{handler: func(op paapi5.Operation, payload map[string]any) ([]byte, error) {
+		if payload["variationPage"] == float64(2) {
+			return nil, failure
+		}
+		return variationsHandler(op, payload)
+	}}
+	rsp, err := GetAllVariations(context.Background(), c, "PARENT")
+	if !errors.Is(err, failure) {
+		t.Fatalf("GetAllVariations() error = %v, want %v", err, failure)
+	}
+	if rsp == nil || len(rsp.VariationsResult.Items) != 2 {
+		t.Errorf("partial response = %+v, want the items of page 1", rsp)
+	}
+	if _, err := GetAllVariations(context.Background(), nil, "PARENT"); !errors.Is(err, paapi5.ErrNullPointer) {
+		t.Errorf("GetAllVariations(nil) error = %v, want %v", err, paapi5.ErrNullPointer)
+	}
+}
+
+// contains reports whether ss holds s.
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
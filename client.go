@@ -3,10 +3,13 @@ package paapi5
 import (
 	"bytes"
 	"context"
+	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/goark/errs"
-	"github.com/goark/fetch"
 )
 
 const (
@@ -14,6 +17,12 @@ const (
 	// marketplaceHeader is the request header used by the Creators API to
 	// select the target Amazon marketplace (e.g. www.amazon.co.jp).
 	marketplaceHeader = "x-marketplace"
+	// maxErrorBodyReadBytes caps how much of a non-2xx API response body we
+	// read into memory to decode the error reply.
+	maxErrorBodyReadBytes = 64 * 1024
+	// maxErrorBodyContextBytes caps how much of a non-2xx API response body
+	// we attach to error contexts.
+	maxErrorBodyContextBytes = 1024
 )
 
 // Query interface for Client type
@@ -31,20 +40,55 @@ type Client interface {
 	RequestContext(context.Context, Query) ([]byte, error)
 }
 
+// StreamClient is a Client that can return response bodies unread.
+type StreamClient interface {
+	Client
+	RequestStream(context.Context, Query) (io.ReadCloser, error)
+}
+
+// RequestStream function returns the response body of q unread if c
+// implements StreamClient (clients created by Server.CreateClient do).
+// For other clients it falls back to RequestContext and wraps the
+// buffered body. The caller must close the returned body.
+func RequestStream(ctx context.Context, c Client, q Query) (io.ReadCloser, error) {
+	if c == nil {
+		return nil, errs.Wrap(ErrNullPointer, errs.WithContext("reason", "nil client"))
+	}
+	if sc, ok := c.(StreamClient); ok {
+		return sc.RequestStream(ctx, q)
+	}
+	b, err := c.RequestContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
 // client is the HTTP client used to call the Amazon Creators API.
 type client struct {
-	server           *Server
-	httpClient       fetch.Client
-	tokenHTTPClient  *http.Client
-	partnerTag       string
-	credentialID     string
-	credentialSecret string
-	version          string
-	authEndpoint     string
-	lwaFlow          bool
-	auth             *tokenManager
+	server            *Server
+	httpClient        *http.Client
+	partnerTag        string
+	credentialID      string
+	credentialSecret  string
+	version           string
+	authEndpoint      string
+	lwaFlow           bool
+	tokenCacheDir     string
+	tokenRefreshAhead float64
+	auth              TokenSource
+	closer            io.Closer
+	retry             *RetryPolicy
+	limiter           *rateLimiter
+	middleware        []Middleware
+	chain             Handler
+	logger            *slog.Logger
+	trace             *ClientTrace
 }
 
+var _ io.Closer = (*client)(nil)    //client is compatible with io.Closer interface
+var _ StreamClient = (*client)(nil) //client is compatible with StreamClient interface
+
 // Marketplace returns the marketplace name (e.g. www.amazon.com).
 func (c *client) Marketplace() string {
 	return c.server.Marketplace()
@@ -62,6 +106,16 @@ func (c *client) PartnerType() string {
 	return defaultPartnerType
 }
 
+// Close stops background work owned by the client, such as the proactive
+// token refresh enabled by WithProactiveTokenRefresh. The client remains
+// usable afterwards. A TokenSource set by WithTokenSource is not closed.
+func (c *client) Close() error {
+	if c.closer == nil {
+		return nil
+	}
+	return c.closer.Close()
+}
+
 // Request issues the supplied query against the Creators API using a
 // background context.
 func (c *client) Request(q Query) ([]byte, error) {
@@ -79,36 +133,116 @@ func (c *client) RequestContext(ctx context.Context, q Query) ([]byte, error) {
 	if err != nil {
 		return nil, errs.Wrap(err, errs.WithContext("Operation", op.String()))
 	}
-	b, err := c.post(ctx, op, payload)
+	b, err := c.chain.Handle(ctx, op, q, payload)
 	if err != nil {
 		return nil, errs.Wrap(err, errs.WithContext("Operation", op.String()), errs.WithContext("payload", string(payload)))
 	}
 	return b, nil
 }
 
-func (c *client) post(ctx context.Context, cmd Operation, payload []byte) ([]byte, error) {
+// RequestStream issues the supplied query against the Creators API like
+// RequestContext, but returns the response body unread so that large
+// responses can be decoded incrementally (see entity.NewItemDecoder).
+// Retries and the token replay happen before the body is returned; the
+// caller must close it. With middleware configured the body is buffered
+// by the chain (see WithMiddleware).
+func (c *client) RequestStream(ctx context.Context, q Query) (io.ReadCloser, error) {
+	if len(c.middleware) > 0 {
+		b, err := c.RequestContext(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+	if q == nil {
+		return nil, errs.Wrap(ErrNullPointer, errs.WithContext("reason", "nil query"))
+	}
+	op := q.Operation()
+	payload, err := q.Payload()
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("Operation", op.String()))
+	}
+	body, err := c.postWithRetry(ctx, op, payload)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("Operation", op.String()), errs.WithContext("payload", string(payload)))
+	}
+	return body, nil
+}
+
+// post issues the catalog request for cmd with the supplied payload.
+// Non-2xx replies are decoded into an APIError carrying the HTTP status,
+// error code, message and request ID.
+//
+// If the API rejects the access token (HTTP 401 or an invalid-token error
+// code) and the TokenSource supports invalidation, the cached token is
+// dropped, a new one is fetched and the request is replayed exactly once.
+func (c *client) post(ctx context.Context, cmd Operation, payload []byte) (io.ReadCloser, error) {
 	u := c.server.URL(cmd.Path())
+	body, token, err := c.send(ctx, cmd, u, payload)
+	if err == nil || !isInvalidToken(err) {
+		return body, err
+	}
+	inv, ok := c.auth.(tokenInvalidator)
+	if !ok {
+		return nil, err
+	}
+	inv.InvalidateToken(token)
+	body, _, err = c.send(ctx, cmd, u, payload)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("replayed", true))
+	}
+	return body, nil
+}
+
+// send takes a rate limiter token and an access token, then POSTs payload
+// to u. It returns the unread body of a 2xx response and the access token
+// used.
+func (c *client) send(ctx context.Context, cmd Operation, u *url.URL, payload []byte) (io.ReadCloser, string, error) {
+	if c.limiter != nil {
+		start := time.Now()
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, "", errs.Wrap(err, errs.WithContext("url", u.String()))
+		}
+		pickTrace(ctx, c.trace).rateLimitWait(ctx, cmd, time.Since(start))
+	}
 	token, err := c.auth.Token(ctx)
 	if err != nil {
-		return nil, errs.Wrap(err, errs.WithContext("url", u.String()))
-	}
-	resp, err := c.httpClient.PostWithContext(
-		ctx,
-		u,
-		bytes.NewReader(payload),
-		fetch.WithRequestHeaderSet("Accept", c.server.Accept()),
-		fetch.WithRequestHeaderSet("Content-Type", c.server.ContentType()),
-		fetch.WithRequestHeaderSet(marketplaceHeader, c.server.Marketplace()),
-		fetch.WithRequestHeaderSet("Authorization", authorizationHeader(token, c.version, c.lwaFlow)),
-	)
+		return nil, "", errs.Wrap(err, errs.WithContext("url", u.String()))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(payload))
 	if err != nil {
-		return nil, errs.Wrap(err, errs.WithContext("url", u.String()), errs.WithContext("payload", string(payload)))
+		return nil, token, errs.Wrap(err, errs.WithContext("url", u.String()))
 	}
-	body, err := resp.DumpBodyAndClose()
+	req.Header.Set("Accept", c.server.Accept())
+	req.Header.Set("Content-Type", c.server.ContentType())
+	req.Header.Set(marketplaceHeader, c.server.Marketplace())
+	req.Header.Set("Authorization", authorizationHeader(token, c.version, c.lwaFlow))
+	c.logBody(ctx, "pa-api request payload", cmd, payload)
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, errs.Wrap(err, errs.WithContext("url", u.String()), errs.WithContext("payload", string(payload)))
+		c.attemptDone(ctx, cmd, payload, 0, time.Since(start), err)
+		return nil, token, errs.Wrap(err, errs.WithContext("url", u.String()), errs.WithContext("payload", string(payload)))
 	}
-	return body, nil
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyReadBytes))
+		apiErr := newAPIError(cmd, resp, body)
+		c.attemptDone(ctx, cmd, payload, resp.StatusCode, time.Since(start), apiErr)
+		c.logBody(ctx, "pa-api response body", cmd, body)
+		return nil, token, errs.Wrap(
+			apiErr,
+			errs.WithContext("url", u.String()),
+			errs.WithContext("status", resp.StatusCode),
+			errs.WithContext("body", truncateForLog(body, maxErrorBodyContextBytes)),
+		)
+	}
+	c.attemptDone(ctx, cmd, payload, resp.StatusCode, time.Since(start), nil)
+	body, err := c.debugBody(ctx, cmd, resp.Body)
+	if err != nil {
+		return nil, token, errs.Wrap(err, errs.WithContext("url", u.String()), errs.WithContext("status", resp.StatusCode))
+	}
+	return body, token, nil
 }
 
 /* Copyright 2019-2021 Spiegel
@@ -327,6 +327,204 @@ func TestClientWithAuthEndpointBeatsCredentialVersion(t *testing.T) {
 	}
 }
 
+func TestClientAPIError(t *testing.T) {
+	tokenHandler := func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "tok",
+			"expires_in":   3600,
+		})
+	}
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-amzn-RequestId", "req-42")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"errors":[{"code":"TooManyRequests","message":"The request was denied due to request throttling."}]}`))
+	}
+	_, _, sv := newServers(t, tokenHandler, apiHandler)
+	c := sv.CreateClient("tag", "id", "secret")
+
+	q := stubQuery{op: SearchItems, payload: []byte("{}")}
+	_, err := c.RequestContext(context.Background(), q)
+	if err == nil {
+		t.Fatal("expected API error, got nil")
+	}
+	if !errors.Is(err, ErrTooManyRequests) {
+		t.Errorf("error chain missing ErrTooManyRequests: %v", err)
+	}
+	if !errors.Is(err, ErrHTTPStatus) {
+		t.Errorf("error chain missing ErrHTTPStatus: %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error chain missing *APIError: %v", err)
+	}
+	if got, want := apiErr.StatusCode, http.StatusTooManyRequests; got != want {
+		t.Errorf("APIError.StatusCode = %d, want %d", got, want)
+	}
+	if got, want := apiErr.Code, "TooManyRequests"; got != want {
+		t.Errorf("APIError.Code = %q, want %q", got, want)
+	}
+	if got, want := apiErr.RequestID, "req-42"; got != want {
+		t.Errorf("APIError.RequestID = %q, want %q", got, want)
+	}
+	if got, want := apiErr.Operation, SearchItems; got != want {
+		t.Errorf("APIError.Operation = %v, want %v", got, want)
+	}
+}
+
+func TestClientWithTokenSource(t *testing.T) {
+	tokenHandler := func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("token endpoint should not be called with an injected TokenSource")
+	}
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer injected, Version 2.2"; got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+		_, _ = w.Write([]byte("{}"))
+	}
+	_, _, sv := newServers(t, tokenHandler, apiHandler)
+	c := sv.CreateClient("tag", "id", "secret",
+		WithCredentialVersion(CredentialVersionEU),
+		WithTokenSource(StaticTokenSource("injected")),
+	)
+
+	q := stubQuery{op: GetItems, payload: []byte("{}")}
+	if _, err := c.RequestContext(context.Background(), q); err != nil {
+		t.Fatalf("RequestContext: %v", err)
+	}
+}
+
+func TestClientTokenSourceError(t *testing.T) {
+	wantErr := errors.New("broker down")
+	c := New().CreateClient("tag", "id", "secret", WithTokenSource(TokenSourceFunc(func(context.Context) (string, error) {
+		return "", wantErr
+	})))
+	_, err := c.RequestContext(context.Background(), stubQuery{op: GetItems, payload: []byte("{}")})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("error chain missing TokenSource error: %v", err)
+	}
+	if _, err := StaticTokenSource("").Token(context.Background()); !errors.Is(err, ErrNoData) {
+		t.Errorf("StaticTokenSource(\"\").Token() error = %v, want ErrNoData", err)
+	}
+	if _, err := TokenSourceFunc(nil).Token(context.Background()); !errors.Is(err, ErrNullPointer) {
+		t.Errorf("TokenSourceFunc(nil).Token() error = %v, want ErrNullPointer", err)
+	}
+}
+
+func TestNewTokenSourceSharedAcrossClients(t *testing.T) {
+	var tokenCalls int32
+	tokenHandler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenCalls, 1)
+		okTokenHandler(w, r)
+	}
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}
+	tokenSrv, _, sv := newServers(t, tokenHandler, apiHandler)
+	ts := NewTokenSource(nil, tokenSrv.URL, CredentialVersionNAv3, "id", "secret")
+
+	q := stubQuery{op: GetItems, payload: []byte("{}")}
+	for _, tag := range []string{"tag-a", "tag-b"} {
+		c := sv.CreateClient(tag, "", "", WithTokenSource(ts))
+		if _, err := c.RequestContext(context.Background(), q); err != nil {
+			t.Fatalf("RequestContext(%s): %v", tag, err)
+		}
+	}
+	if got, want := atomic.LoadInt32(&tokenCalls), int32(1); got != want {
+		t.Errorf("token endpoint hit %d times, want %d", got, want)
+	}
+}
+
+func TestClientReplaysOnceAfterRejectedToken(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rejects  int32 // number of leading API calls answered with 401
+		options  []ClientOptFunc
+		apiCalls int32
+		tokens   int32
+		wantErr  bool
+	}{
+		{name: "replay succeeds", rejects: 1, apiCalls: 2, tokens: 2},
+		{name: "replay rejected too", rejects: 2, apiCalls: 2, tokens: 2, wantErr: true},
+		{name: "token source without invalidation", rejects: 1, options: []ClientOptFunc{WithTokenSource(StaticTokenSource("static"))}, apiCalls: 1, tokens: 0, wantErr: true},
+		{name: "disk cache", rejects: 1, options: []ClientOptFunc{WithTokenCacheDir(t.TempDir())}, apiCalls: 2, tokens: 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var tokenCalls, apiCalls int32
+			tokenHandler := func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&tokenCalls, 1)
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"access_token": fmt.Sprintf("tok-%d", n),
+					"expires_in":   3600,
+				})
+			}
+			apiHandler := func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&apiCalls, 1) <= tc.rejects {
+					w.WriteHeader(http.StatusUnauthorized)
+					_, _ = w.Write([]byte(`{"errors":[{"code":"InvalidToken","message":"token revoked"}]}`))
+					return
+				}
+				_, _ = w.Write([]byte("{}"))
+			}
+			_, _, sv := newServers(t, tokenHandler, apiHandler)
+			c := sv.CreateClient("tag", "id", "secret", tc.options...)
+
+			_, err := c.RequestContext(context.Background(), stubQuery{op: GetItems, payload: []byte("{}")})
+			if tc.wantErr {
+				if !errors.Is(err, ErrUnauthorized) {
+					t.Errorf("error = %v, want ErrUnauthorized", err)
+				}
+			} else if err != nil {
+				t.Errorf("RequestContext: %+v", err)
+			}
+			if got := atomic.LoadInt32(&apiCalls); got != tc.apiCalls {
+				t.Errorf("api endpoint hit %d times, want %d", got, tc.apiCalls)
+			}
+			if got := atomic.LoadInt32(&tokenCalls); got != tc.tokens {
+				t.Errorf("token endpoint hit %d times, want %d", got, tc.tokens)
+			}
+		})
+	}
+}
+
+func TestClientRequestStream(t *testing.T) {
+	calls := 0
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"itemsResult":{"items":[{"asin":"A1"}]}}`))
+	}
+	_, _, sv := newServers(t, okTokenHandler, apiHandler)
+	c := sv.CreateClient("tag", "id", "secret", WithRetryPolicy(fastRetryPolicy(2)))
+
+	q := stubQuery{op: GetItems, payload: []byte("{}")}
+	body, err := RequestStream(context.Background(), c, q)
+	if err != nil {
+		t.Fatalf("RequestStream: %+v", err)
+	}
+	defer body.Close()
+	b, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if got, want := string(b), `{"itemsResult":{"items":[{"asin":"A1"}]}}`; got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+
+	if _, err := RequestStream(context.Background(), c, stubQuery{op: GetItems, err: errors.New("bad payload")}); err == nil {
+		t.Error("RequestStream with a payload error should fail")
+	}
+	if _, err := RequestStream(context.Background(), nil, q); !errors.Is(err, ErrNullPointer) {
+		t.Errorf("RequestStream(nil) error = %v, want %v", err, ErrNullPointer)
+	}
+}
+
 func TestClientPayloadError(t *testing.T) {
 	c := New().CreateClient("tag", "id", "secret")
 	wantErr := errors.New("payload boom")
@@ -0,0 +1,168 @@
+package paapi5
+
+import (
+	"context"
+	"time"
+)
+
+// ClientTrace type is a set of hooks run at various stages of a request,
+// for instrumentation such as tracing and metrics. Any field may be nil.
+// Hooks may be called concurrently and must not block.
+//
+// A ClientTrace is attached to a context with WithClientTrace, or to a
+// client with WithTrace. When both are present the hooks of both run, the
+// ones of the context first.
+type ClientTrace struct {
+	// RateLimitWait is called after the rate limiter (see WithRateLimit)
+	// let an attempt through, with the time spent waiting.
+	RateLimitWait func(ctx context.Context, op Operation, wait time.Duration)
+	// AttemptDone is called after each HTTP attempt.
+	AttemptDone func(ctx context.Context, info AttemptInfo)
+	// Retry is called before waiting for the next attempt (see
+	// WithRetryPolicy). attempt is the number of the failed attempt.
+	Retry func(ctx context.Context, op Operation, attempt int, wait time.Duration, err error)
+	// TokenRefresh is called when the built-in token manager starts to
+	// obtain a new OAuth2 access token, from the on-disk cache or the
+	// token endpoint. The returned context, if not nil, is used for the
+	// refresh, and done (if not nil) is called with its outcome.
+	// Background refreshes (see WithProactiveTokenRefresh) only see the
+	// ClientTrace set by WithTrace.
+	TokenRefresh func(ctx context.Context) (refreshCtx context.Context, done func(err error))
+}
+
+// AttemptInfo type describes one HTTP attempt of a request.
+type AttemptInfo struct {
+	Operation   Operation
+	Marketplace string
+	// StatusCode is the HTTP status of the reply, or zero if none arrived.
+	StatusCode int
+	// Latency is the time from sending the request to receiving the
+	// response headers.
+	Latency time.Duration
+	// Err is the failure of the attempt, if any (an *APIError for non-2xx
+	// replies).
+	Err error
+}
+
+type clientTraceKey struct{}
+
+// WithClientTrace function returns a new context based on ctx whose
+// requests run the hooks of trace.
+func WithClientTrace(ctx context.Context, trace *ClientTrace) context.Context {
+	return context.WithValue(ctx, clientTraceKey{}, trace)
+}
+
+// ContextClientTrace function returns the ClientTrace attached to ctx, or
+// nil if there is none.
+func ContextClientTrace(ctx context.Context) *ClientTrace {
+	if ctx == nil {
+		return nil
+	}
+	trace, _ := ctx.Value(clientTraceKey{}).(*ClientTrace)
+	return trace
+}
+
+// WithTrace function returns a ClientOptFunc that runs the hooks of trace
+// for every request of the client and for background token refreshes.
+func WithTrace(trace *ClientTrace) ClientOptFunc {
+	return func(c *client) {
+		if c != nil && trace != nil {
+			c.trace = trace
+		}
+	}
+}
+
+// pickTrace returns the ClientTrace of ctx combined with def.
+func pickTrace(ctx context.Context, def *ClientTrace) *ClientTrace {
+	trace := ContextClientTrace(ctx)
+	switch {
+	case trace == nil:
+		return def
+	case def == nil || def == trace:
+		return trace
+	}
+	return &ClientTrace{
+		RateLimitWait: func(ctx context.Context, op Operation, wait time.Duration) {
+			trace.rateLimitWait(ctx, op, wait)
+			def.rateLimitWait(ctx, op, wait)
+		},
+		AttemptDone: func(ctx context.Context, info AttemptInfo) {
+			trace.attemptDone(ctx, info)
+			def.attemptDone(ctx, info)
+		},
+		Retry: func(ctx context.Context, op Operation, attempt int, wait time.Duration, err error) {
+			trace.retry(ctx, op, attempt, wait, err)
+			def.retry(ctx, op, attempt, wait, err)
+		},
+		TokenRefresh: func(ctx context.Context) (context.Context, func(error)) {
+			ctx, done := trace.tokenRefresh(ctx)
+			ctx, defDone := def.tokenRefresh(ctx)
+			return ctx, func(err error) {
+				defDone(err)
+				done(err)
+			}
+		},
+	}
+}
+
+func (trace *ClientTrace) rateLimitWait(ctx context.Context, op Operation, wait time.Duration) {
+	if trace != nil && trace.RateLimitWait != nil {
+		trace.RateLimitWait(ctx, op, wait)
+	}
+}
+
+func (trace *ClientTrace) attemptDone(ctx context.Context, info AttemptInfo) {
+	if trace != nil && trace.AttemptDone != nil {
+		trace.AttemptDone(ctx, info)
+	}
+}
+
+func (trace *ClientTrace) retry(ctx context.Context, op Operation, attempt int, wait time.Duration, err error) {
+	if trace != nil && trace.Retry != nil {
+		trace.Retry(ctx, op, attempt, wait, err)
+	}
+}
+
+// tokenRefresh runs the TokenRefresh hook and returns the context for the
+// refresh and a non-nil completion function.
+func (trace *ClientTrace) tokenRefresh(ctx context.Context) (context.Context, func(error)) {
+	if trace == nil || trace.TokenRefresh == nil {
+		return ctx, func(error) {}
+	}
+	refreshCtx, done := trace.TokenRefresh(ctx)
+	if refreshCtx == nil {
+		refreshCtx = ctx
+	}
+	if done == nil {
+		done = func(error) {}
+	}
+	return refreshCtx, done
+}
+
+// attemptDone reports the outcome of one HTTP attempt of op to the logger
+// and the ClientTrace.
+func (c *client) attemptDone(ctx context.Context, op Operation, payload []byte, status int, latency time.Duration, err error) {
+	c.logRequest(ctx, op, payload, status, latency, err)
+	pickTrace(ctx, c.trace).attemptDone(ctx, AttemptInfo{
+		Operation:   op,
+		Marketplace: c.Marketplace(),
+		StatusCode:  status,
+		Latency:     latency,
+		Err:         err,
+	})
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
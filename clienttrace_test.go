@@ -0,0 +1,141 @@
+package paapi5
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// traceRecorder collects the events of a ClientTrace.
+type traceRecorder struct {
+	mu       sync.Mutex
+	events   []string
+	attempts []AttemptInfo
+	refresh  []error
+}
+
+type traceMarker struct{}
+
+func (r *traceRecorder) add(ev string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+}
+
+func (r *traceRecorder) trace() *ClientTrace {
+	return &ClientTrace{
+		RateLimitWait: func(ctx context.Context, op Operation, wait time.Duration) {
+			r.add("wait " + op.String())
+		},
+		AttemptDone: func(ctx context.Context, info AttemptInfo) {
+			r.add("attempt " + info.Operation.String())
+			r.mu.Lock()
+			r.attempts = append(r.attempts, info)
+			r.mu.Unlock()
+		},
+		Retry: func(ctx context.Context, op Operation, attempt int, wait time.Duration, err error) {
+			r.add("retry " + op.String())
+		},
+		TokenRefresh: func(ctx context.Context) (context.Context, func(error)) {
+			r.add("refresh")
+			return context.WithValue(ctx, traceMarker{}, true), func(err error) {
+				r.mu.Lock()
+				r.refresh = append(r.refresh, err)
+				r.mu.Unlock()
+			}
+		},
+	}
+}
+
+func TestClientTrace(t *testing.T) {
+	calls := 0
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}
+	_, _, sv := newServers(t, okTokenHandler, apiHandler)
+	def, ctxRec := &traceRecorder{}, &traceRecorder{}
+	c := sv.CreateClient("tag", "id", "secret",
+		WithRetryPolicy(fastRetryPolicy(2)),
+		WithRateLimit(1000, 10, 0),
+		WithTrace(def.trace()),
+		WithHttpClient(&http.Client{Transport: markerTransport{t: t}}),
+	)
+
+	ctx := WithClientTrace(context.Background(), ctxRec.trace())
+	if got := ContextClientTrace(ctx); got == nil {
+		t.Fatal("ContextClientTrace() = nil")
+	}
+	if _, err := c.RequestContext(ctx, stubQuery{op: SearchItems, payload: []byte(`{}`)}); err != nil {
+		t.Fatalf("RequestContext: %+v", err)
+	}
+	want := []string{"wait SearchItems", "refresh", "attempt SearchItems", "retry SearchItems", "wait SearchItems", "attempt SearchItems"}
+	if len(ctxRec.events) != len(want) {
+		t.Fatalf("events = %v, want %v", ctxRec.events, want)
+	}
+	for i := range want {
+		if ctxRec.events[i] != want[i] {
+			t.Errorf("events = %v, want %v", ctxRec.events, want)
+			break
+		}
+	}
+	if len(def.events) != len(want) || len(def.refresh) != 1 {
+		t.Errorf("client-level events = %v, want %v", def.events, want)
+	}
+	first, last := ctxRec.attempts[0], ctxRec.attempts[1]
+	var apiErr *APIError
+	if first.StatusCode != http.StatusTooManyRequests || !errors.As(first.Err, &apiErr) || first.Marketplace != "www.amazon.com" {
+		t.Errorf("first attempt = %+v", first)
+	}
+	if last.StatusCode != http.StatusOK || last.Err != nil || last.Latency <= 0 {
+		t.Errorf("last attempt = %+v", last)
+	}
+	if len(ctxRec.refresh) != 1 || ctxRec.refresh[0] != nil {
+		t.Errorf("token refresh outcomes = %v", ctxRec.refresh)
+	}
+
+	if _, err := c.RequestContext(context.Background(), stubQuery{op: GetItems, payload: []byte(`{}`)}); err != nil {
+		t.Fatalf("RequestContext: %+v", err)
+	}
+	if got := def.events[len(want):]; len(got) != 2 || got[0] != "wait GetItems" || got[1] != "attempt GetItems" {
+		t.Errorf("client-level events = %v, want [wait GetItems attempt GetItems]", got)
+	}
+	if len(ctxRec.events) != len(want) {
+		t.Errorf("context trace saw %v after the context was dropped", ctxRec.events[len(want):])
+	}
+}
+
+// markerTransport checks that token requests carry the context returned by
+// the TokenRefresh hook.
+type markerTransport struct {
+	t *testing.T
+}
+
+func (m markerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Content-Type") == "application/x-www-form-urlencoded" && req.Context().Value(traceMarker{}) == nil {
+		m.t.Error("token request does not carry the TokenRefresh context")
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -8,376 +8,462 @@ import (
 )
 
 type Image struct {
-	URL    string
-	Height int
-	Width  int
+	URL    string `json:"url,omitempty"`
+	Height int    `json:"height"`
+	Width  int    `json:"width"`
 }
 
 type GenInfo struct {
-	DisplayValue string
-	Label        string `json:",omitempty"`
-	Locale       string `json:",omitempty"`
+	DisplayValue string `json:"displayValue,omitempty"`
+	Label        string `json:"label,omitempty"`
+	Locale       string `json:"locale,omitempty"`
 }
 
 type GenInfoInt struct {
-	DisplayValue int
-	Label        string `json:",omitempty"`
-	Locale       string `json:",omitempty"`
+	DisplayValue int    `json:"displayValue"`
+	Label        string `json:"label,omitempty"`
+	Locale       string `json:"locale,omitempty"`
 }
 
 type GenInfoFloat struct {
-	DisplayValue float64
-	Label        string `json:",omitempty"`
-	Locale       string `json:",omitempty"`
-	Unit         string `json:",omitempty"`
+	DisplayValue float64 `json:"displayValue"`
+	Label        string  `json:"label,omitempty"`
+	Locale       string  `json:"locale,omitempty"`
+	Unit         string  `json:"unit,omitempty"`
 }
 
 type GenInfoTime struct {
-	DisplayValue Date
-	Label        string `json:",omitempty"`
-	Locale       string `json:",omitempty"`
+	DisplayValue Date   `json:"displayValue,omitzero"`
+	Label        string `json:"label,omitempty"`
+	Locale       string `json:"locale,omitempty"`
 }
 
 type IdInfo struct {
-	DisplayValues []string
-	Label         string `json:",omitempty"`
-	Locale        string `json:",omitempty"`
+	DisplayValues []string `json:"displayValues,omitempty"`
+	Label         string   `json:"label,omitempty"`
+	Locale        string   `json:"locale,omitempty"`
 }
 
 type Ancestor struct {
-	Id              string
-	DisplayName     string
-	ContextFreeName string
-	Ancestor        *Ancestor `json:",omitempty"`
+	Id              string    `json:"id,omitempty"`
+	DisplayName     string    `json:"displayName,omitempty"`
+	ContextFreeName string    `json:"contextFreeName,omitempty"`
+	Ancestor        *Ancestor `json:"ancestor,omitempty"`
 }
 
 type ConditionInfo struct {
-	DisplayValue string         `json:",omitempty"`
-	Label        string         `json:",omitempty"`
-	Locale       string         `json:",omitempty"`
-	Value        string         `json:",omitempty"`
-	SubCondition *ConditionInfo `json:",omitempty"`
+	DisplayValue string         `json:"displayValue,omitempty"`
+	Label        string         `json:"label,omitempty"`
+	Locale       string         `json:"locale,omitempty"`
+	Value        string         `json:"value,omitempty"`
+	SubCondition *ConditionInfo `json:"subCondition,omitempty"`
 }
 
 type ConditionInfoV2 struct {
-	ConditionNote string `json:",omitempty"`
-	Value         string `json:",omitempty"`
-	SubCondition  string `json:",omitempty"`
+	ConditionNote string `json:"conditionNote,omitempty"`
+	Value         string `json:"value,omitempty"`
+	SubCondition  string `json:"subCondition,omitempty"`
 }
 
 type GenPriceInfo struct {
-	Amount        float64 `json:",omitempty"`
-	Currency      string  `json:",omitempty"`
-	DisplayAmount string  `json:",omitempty"`
-	PricePerUnit  float64 `json:",omitempty"`
+	Amount        float64 `json:"amount"`
+	Currency      string  `json:"currency,omitempty"`
+	DisplayAmount string  `json:"displayAmount,omitempty"`
+	PricePerUnit  float64 `json:"pricePerUnit,omitempty"`
 }
 
 type Money struct {
-	Amount        float64 `json:",omitempty"`
-	Currency      string  `json:",omitempty"`
-	DisplayAmount string  `json:",omitempty"`
+	Amount        float64 `json:"amount"`
+	Currency      string  `json:"currency,omitempty"`
+	DisplayAmount string  `json:"displayAmount,omitempty"`
 }
 
 type SavingBasis struct {
-	Money                *Money `json:",omitempty"`
-	SavingBasisType      string `json:",omitempty"`
-	SavingBasisTypeLabel string `json:",omitempty"`
+	Money                *Money `json:"money,omitempty"`
+	SavingBasisType      string `json:"savingBasisType,omitempty"`
+	SavingBasisTypeLabel string `json:"savingBasisTypeLabel,omitempty"`
 }
 
 type VariationAttribute struct {
-	Name  string `json:",omitempty"`
-	Value string `json:",omitempty"`
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
 }
 
 type Item struct {
-	ASIN          string
-	ParentASIN    string
-	DetailPageURL string
+	ASIN          string `json:"asin"`
+	ParentASIN    string `json:"parentASIN,omitempty"`
+	DetailPageURL string `json:"detailPageURL,omitempty"`
 	// Deprecated: not described in current Creators API documentation; retained for JSON compatibility.
-	Score           *float64 `json:",omitempty"`
-	CustomerReviews *struct {
-		Count      *int `json:",omitempty"`
-		StarRating *struct {
-			Value *float64 `json:",omitempty"`
-		} `json:",omitempty"`
-	} `json:",omitempty"`
-	BrowseNodeInfo *struct {
-		BrowseNodes []struct {
-			Id              string
-			DisplayName     string
-			ContextFreeName string
-			IsRoot          bool
-			// Deprecated: not described in current Creators API documentation; retained for JSON compatibility.
-			SalesRank        *int      `json:",omitempty"`
-			Ancestor         *Ancestor `json:",omitempty"`
-			WebsiteSalesRank *struct {
-				// Deprecated: not described in current Creators API documentation; retained for JSON compatibility.
-				Id              string `json:"id,omitempty"`
-				DisplayName     string
-				ContextFreeName string
-				SalesRank       int
-			} `json:",omitempty"`
-		} `json:",omitempty"`
-	} `json:",omitempty"`
-	Images *struct {
-		Primary *struct {
-			Large  *Image `json:",omitempty"`
-			Medium *Image `json:",omitempty"`
-			Small  *Image `json:",omitempty"`
-			// Deprecated: high-resolution image keys are not documented resource paths for Creators API; retained for JSON compatibility.
-			HiRes *Image `json:"hiRes,omitempty"`
-		} `json:",omitempty"`
-		Variants []*struct {
-			Large  *Image `json:",omitempty"`
-			Medium *Image `json:",omitempty"`
-			Small  *Image `json:",omitempty"`
-			// Deprecated: high-resolution image keys are not documented resource paths for Creators API; retained for JSON compatibility.
-			HiRes *Image `json:"hiRes,omitempty"`
-		} `json:",omitempty"`
-	} `json:",omitempty"`
-	ItemInfo *struct {
-		ByLineInfo *struct {
-			Brand        *GenInfo `json:",omitempty"`
-			Manufacturer *GenInfo `json:",omitempty"`
-			Contributors []struct {
-				Name   string
-				Locale string
-				Role   string
-			}
-		} `json:",omitempty"`
-		Classifications *struct {
-			Binding      GenInfo
-			ProductGroup GenInfo
-		} `json:",omitempty"`
-		ContentInfo *struct {
-			Edition   *GenInfo `json:",omitempty"`
-			Languages struct {
-				DisplayValues []struct {
-					DisplayValue string
-					Type         string
-				}
-				Label  string
-				Locale string
-			}
-			PagesCount struct {
-				DisplayValue int
-				Label        string
-				Locale       string
-			}
-			PublicationDate GenInfoTime
-		} `json:",omitempty"`
-		ContentRating *struct {
-			AudienceRating GenInfo
-		} `json:",omitempty"`
-		ExternalIds *struct {
-			EANs  *IdInfo `json:",omitempty"`
-			ISBNs *IdInfo `json:",omitempty"`
-			UPCs  *IdInfo `json:",omitempty"`
-		} `json:",omitempty"`
-		Features        *IdInfo `json:",omitempty"`
-		ManufactureInfo *struct {
-			ItemPartNumber *GenInfo `json:",omitempty"`
-			Model          *GenInfo `json:",omitempty"`
-			Warranty       *GenInfo `json:",omitempty"`
-		} `json:",omitempty"`
-		ProductInfo *struct {
-			Color          *GenInfo `json:",omitempty"`
-			IsAdultProduct struct {
-				DisplayValue bool
-				Label        string
-				Locale       string
-			}
-			ItemDimensions *struct {
-				Height *GenInfoFloat `json:",omitempty"`
-				Length *GenInfoFloat `json:",omitempty"`
-				Weight *GenInfoFloat `json:",omitempty"`
-				Width  *GenInfoFloat `json:",omitempty"`
-			} `json:",omitempty"`
-			ReleaseDate *GenInfoTime `json:",omitempty"`
-			Size        *GenInfo     `json:",omitempty"`
-			UnitCount   *GenInfoInt  `json:",omitempty"`
-		} `json:",omitempty"`
-		TechnicalInfo *struct {
-			Formats IdInfo
-		} `json:",omitempty"`
-		Title       *GenInfo `json:",omitempty"`
-		TradeInInfo *struct {
-			IsEligibleForTradeIn bool
-			Price                struct {
-				DisplayAmount string
-				Amount        float64
-				Currency      string
-			}
-		} `json:",omitempty"`
-	}
-	VariationAttributes []VariationAttribute `json:",omitempty"`
-	Offers              *struct {
-		Listings *[]struct {
-			Availability *struct {
-				MaxOrderQuantity int
-				Message          string
-				MinOrderQuantity int
-				Type             string
-			} `json:",omitempty"`
-			Condition    *ConditionInfo `json:",omitempty"`
-			DeliveryInfo *struct {
-				IsAmazonFulfilled      bool `json:",omitempty"`
-				IsFreeShippingEligible bool `json:",omitempty"`
-				IsPrimeEligible        bool `json:",omitempty"`
-			} `json:",omitempty"`
-			ID             string `json:"id"`
-			IsBuyboxWinner bool
-			LoyaltyPoints  *struct {
-				Points int
-			} `json:",omitempty"`
-			MerchantInfo *struct {
-				DefaultShippingCountry string
-				FeedbackCount          int
-				FeedbackRating         float64
-				ID                     string `json:"id"`
-				Name                   string
-			} `json:",omitempty"`
-			Price *struct {
-				*GenPriceInfo `json:",omitempty"`
-				Savings       *struct {
-					Amount        float64
-					Currency      string
-					DisplayAmount string
-					Percentage    int
-					PricePerUnit  float64
-				} `json:",omitempty"`
-			} `json:",omitempty"`
-			ProgramEligibility *struct {
-				IsPrimeExclusive bool
-				IsPrimePantry    bool
-			} `json:",omitempty"`
-			Promotions *[]struct {
-				Amount          float64
-				Currency        string
-				DiscountPercent json.Number
-				DisplayAmount   string
-				PricePerUnit    float64
-				Type            string
-			} `json:",omitempty"`
-			SavingBasis *GenPriceInfo `json:",omitempty"`
-			ViolateMAP  bool
-		} `json:",omitempty"`
-		Summaries *[]struct {
-			Condition    *ConditionInfo `json:",omitempty"`
-			HighestPrice *GenPriceInfo  `json:",omitempty"`
-			LowestPrice  *GenPriceInfo  `json:",omitempty"`
-			OfferCount   int
-		} `json:",omitempty"`
-	} `json:",omitempty"`
-	OffersV2 *struct {
-		Listings *[]struct {
-			Availability *struct {
-				MaxOrderQuantity int
-				Message          string
-				MinOrderQuantity int
-				Type             string
-			} `json:",omitempty"`
-			Condition   *ConditionInfoV2 `json:",omitempty"`
-			DealDetails *struct {
-				AccessType                        string `json:",omitempty"`
-				Badge                             string `json:",omitempty"`
-				EarlyAccessDurationInMilliseconds int64  `json:",omitempty"`
-				EndTime                           string `json:",omitempty"`
-				PercentClaimed                    int    `json:",omitempty"`
-				StartTime                         string `json:",omitempty"`
-			} `json:",omitempty"`
-			IsBuyboxWinner bool
-			LoyaltyPoints  *struct {
-				Points int
-			} `json:",omitempty"`
-			MerchantInfo *struct {
-				ID   string `json:"id"`
-				Name string
-			} `json:",omitempty"`
-			Price *struct {
-				Money        *Money       `json:",omitempty"`
-				PricePerUnit *Money       `json:",omitempty"`
-				SavingBasis  *SavingBasis `json:",omitempty"`
-				Savings      *struct {
-					Money      *Money `json:",omitempty"`
-					Percentage int
-				} `json:",omitempty"`
-			} `json:",omitempty"`
-			Type        string `json:",omitempty"`
-			ViolatesMAP bool   `json:"violatesMAP,omitempty"`
-		} `json:",omitempty"`
-	} `json:",omitempty"`
+	Score               *float64             `json:"score,omitempty"`
+	CustomerReviews     *CustomerReviews     `json:"customerReviews,omitempty"`
+	BrowseNodeInfo      *BrowseNodeInfo      `json:"browseNodeInfo,omitempty"`
+	Images              *Images              `json:"images,omitempty"`
+	ItemInfo            *ItemInfo            `json:"itemInfo,omitempty"`
+	VariationAttributes []VariationAttribute `json:"variationAttributes,omitempty"`
+	Offers              *Offers              `json:"offers,omitempty"`
+	OffersV2            *OffersV2            `json:"offersV2,omitempty"`
+}
+
+type CustomerReviews struct {
+	Count      *int        `json:"count,omitempty"`
+	StarRating *StarRating `json:"starRating,omitempty"`
+}
+
+type StarRating struct {
+	Value *float64 `json:"value,omitempty"`
+}
+
+type BrowseNodeInfo struct {
+	BrowseNodes []BrowseNode `json:"browseNodes,omitempty"`
+}
+
+type BrowseNode struct {
+	Id              string `json:"id,omitempty"`
+	DisplayName     string `json:"displayName,omitempty"`
+	ContextFreeName string `json:"contextFreeName,omitempty"`
+	IsRoot          bool   `json:"isRoot"`
+	// Deprecated: not described in current Creators API documentation; retained for JSON compatibility.
+	SalesRank        *int              `json:"salesRank,omitempty"`
+	Ancestor         *Ancestor         `json:"ancestor,omitempty"`
+	WebsiteSalesRank *WebsiteSalesRank `json:"websiteSalesRank,omitempty"`
+}
+
+type WebsiteSalesRank struct {
+	// Deprecated: not described in current Creators API documentation; retained for JSON compatibility.
+	Id              string `json:"id,omitempty"`
+	DisplayName     string `json:"displayName,omitempty"`
+	ContextFreeName string `json:"contextFreeName,omitempty"`
+	SalesRank       int    `json:"salesRank"`
+}
+
+type Images struct {
+	Primary  *ImageSet   `json:"primary,omitempty"`
+	Variants []*ImageSet `json:"variants,omitempty"`
+}
+
+type ImageSet struct {
+	Large  *Image `json:"large,omitempty"`
+	Medium *Image `json:"medium,omitempty"`
+	Small  *Image `json:"small,omitempty"`
+	// Deprecated: high-resolution image keys are not documented resource paths for Creators API; retained for JSON compatibility.
+	HiRes *Image `json:"hiRes,omitempty"`
+}
+
+type ItemInfo struct {
+	ByLineInfo      *ByLineInfo      `json:"byLineInfo,omitempty"`
+	Classifications *Classifications `json:"classifications,omitempty"`
+	ContentInfo     *ContentInfo     `json:"contentInfo,omitempty"`
+	ContentRating   *ContentRating   `json:"contentRating,omitempty"`
+	ExternalIds     *ExternalIds     `json:"externalIds,omitempty"`
+	Features        *IdInfo          `json:"features,omitempty"`
+	ManufactureInfo *ManufactureInfo `json:"manufactureInfo,omitempty"`
+	ProductInfo     *ProductInfo     `json:"productInfo,omitempty"`
+	TechnicalInfo   *TechnicalInfo   `json:"technicalInfo,omitempty"`
+	Title           *GenInfo         `json:"title,omitempty"`
+	TradeInInfo     *TradeInInfo     `json:"tradeInInfo,omitempty"`
+}
+
+type ByLineInfo struct {
+	Brand        *GenInfo      `json:"brand,omitempty"`
+	Manufacturer *GenInfo      `json:"manufacturer,omitempty"`
+	Contributors []Contributor `json:"contributors,omitempty"`
+}
+
+type Contributor struct {
+	Name     string `json:"name,omitempty"`
+	Locale   string `json:"locale,omitempty"`
+	Role     string `json:"role,omitempty"`
+	RoleType string `json:"roleType,omitempty"`
+}
+
+type Classifications struct {
+	Binding      GenInfo `json:"binding,omitzero"`
+	ProductGroup GenInfo `json:"productGroup,omitzero"`
+}
+
+type ContentInfo struct {
+	Edition         *GenInfo    `json:"edition,omitempty"`
+	Languages       Languages   `json:"languages,omitzero"`
+	PagesCount      PagesCount  `json:"pagesCount,omitzero"`
+	PublicationDate GenInfoTime `json:"publicationDate,omitzero"`
+}
+
+type Languages struct {
+	DisplayValues []Language `json:"displayValues,omitempty"`
+	Label         string     `json:"label,omitempty"`
+	Locale        string     `json:"locale,omitempty"`
+}
+
+type Language struct {
+	DisplayValue string `json:"displayValue,omitempty"`
+	Type         string `json:"type,omitempty"`
+}
+
+type PagesCount struct {
+	DisplayValue int    `json:"displayValue"`
+	Label        string `json:"label,omitempty"`
+	Locale       string `json:"locale,omitempty"`
+}
+
+type ContentRating struct {
+	AudienceRating GenInfo `json:"audienceRating,omitzero"`
+}
+
+type ExternalIds struct {
+	EANs  *IdInfo `json:"eans,omitempty"`
+	ISBNs *IdInfo `json:"isbns,omitempty"`
+	UPCs  *IdInfo `json:"upcs,omitempty"`
+}
+
+type ManufactureInfo struct {
+	ItemPartNumber *GenInfo `json:"itemPartNumber,omitempty"`
+	Model          *GenInfo `json:"model,omitempty"`
+	Warranty       *GenInfo `json:"warranty,omitempty"`
+}
+
+type ProductInfo struct {
+	Color          *GenInfo        `json:"color,omitempty"`
+	IsAdultProduct IsAdultProduct  `json:"isAdultProduct,omitzero"`
+	ItemDimensions *ItemDimensions `json:"itemDimensions,omitempty"`
+	ReleaseDate    *GenInfoTime    `json:"releaseDate,omitempty"`
+	Size           *GenInfo        `json:"size,omitempty"`
+	UnitCount      *GenInfoInt     `json:"unitCount,omitempty"`
+}
+
+type IsAdultProduct struct {
+	DisplayValue bool   `json:"displayValue"`
+	Label        string `json:"label,omitempty"`
+	Locale       string `json:"locale,omitempty"`
+}
+
+type ItemDimensions struct {
+	Height *GenInfoFloat `json:"height,omitempty"`
+	Length *GenInfoFloat `json:"length,omitempty"`
+	Weight *GenInfoFloat `json:"weight,omitempty"`
+	Width  *GenInfoFloat `json:"width,omitempty"`
+}
+
+type TechnicalInfo struct {
+	Formats IdInfo `json:"formats,omitzero"`
+}
+
+type TradeInInfo struct {
+	IsEligibleForTradeIn bool  `json:"isEligibleForTradeIn"`
+	Price                Price `json:"price,omitzero"`
+}
+
+type Offers struct {
+	Listings  *[]OfferListing `json:"listings,omitempty"`
+	Summaries *[]OfferSummary `json:"summaries,omitempty"`
+}
+
+type OfferListing struct {
+	Availability       *OfferAvailability       `json:"availability,omitempty"`
+	Condition          *ConditionInfo           `json:"condition,omitempty"`
+	DeliveryInfo       *OfferDeliveryInfo       `json:"deliveryInfo,omitempty"`
+	ID                 string                   `json:"id,omitempty"`
+	IsBuyboxWinner     bool                     `json:"isBuyBoxWinner"`
+	LoyaltyPoints      *LoyaltyPoints           `json:"loyaltyPoints,omitempty"`
+	MerchantInfo       *OfferMerchantInfo       `json:"merchantInfo,omitempty"`
+	Price              *OfferPrice              `json:"price,omitempty"`
+	ProgramEligibility *OfferProgramEligibility `json:"programEligibility,omitempty"`
+	Promotions         *[]OfferPromotion        `json:"promotions,omitempty"`
+	SavingBasis        *GenPriceInfo            `json:"savingBasis,omitempty"`
+	ViolateMAP         bool                     `json:"violatesMAP"`
+}
+
+type OfferAvailability struct {
+	MaxOrderQuantity int    `json:"maxOrderQuantity"`
+	Message          string `json:"message,omitempty"`
+	MinOrderQuantity int    `json:"minOrderQuantity"`
+	Type             string `json:"type,omitempty"`
+}
+
+type OfferDeliveryInfo struct {
+	IsAmazonFulfilled      bool `json:"isAmazonFulfilled"`
+	IsFreeShippingEligible bool `json:"isFreeShippingEligible"`
+	IsPrimeEligible        bool `json:"isPrimeEligible"`
+}
+
+type LoyaltyPoints struct {
+	Points int `json:"points"`
+}
+
+type OfferMerchantInfo struct {
+	DefaultShippingCountry string  `json:"defaultShippingCountry,omitempty"`
+	FeedbackCount          int     `json:"feedbackCount"`
+	FeedbackRating         float64 `json:"feedbackRating"`
+	ID                     string  `json:"id,omitempty"`
+	Name                   string  `json:"name,omitempty"`
+}
+
+type OfferPrice struct {
+	*GenPriceInfo `json:",omitempty"`
+	Savings       *OfferSavings `json:"savings,omitempty"`
+}
+
+type OfferSavings struct {
+	Amount        float64 `json:"amount"`
+	Currency      string  `json:"currency,omitempty"`
+	DisplayAmount string  `json:"displayAmount,omitempty"`
+	Percentage    int     `json:"percentage"`
+	PricePerUnit  float64 `json:"pricePerUnit,omitempty"`
+}
+
+type OfferProgramEligibility struct {
+	IsPrimeExclusive bool `json:"isPrimeExclusive"`
+	IsPrimePantry    bool `json:"isPrimePantry"`
+}
+
+type OfferPromotion struct {
+	Amount          float64     `json:"amount"`
+	Currency        string      `json:"currency,omitempty"`
+	DiscountPercent json.Number `json:"discountPercent,omitempty"`
+	DisplayAmount   string      `json:"displayAmount,omitempty"`
+	PricePerUnit    float64     `json:"pricePerUnit,omitempty"`
+	Type            string      `json:"type,omitempty"`
+}
+
+type OfferSummary struct {
+	Condition    *ConditionInfo `json:"condition,omitempty"`
+	HighestPrice *GenPriceInfo  `json:"highestPrice,omitempty"`
+	LowestPrice  *GenPriceInfo  `json:"lowestPrice,omitempty"`
+	OfferCount   int            `json:"offerCount"`
+}
+
+type OffersV2 struct {
+	Listings *[]OfferListingV2 `json:"listings,omitempty"`
+}
+
+type OfferListingV2 struct {
+	Availability   *OfferAvailability   `json:"availability,omitempty"`
+	Condition      *ConditionInfoV2     `json:"condition,omitempty"`
+	DealDetails    *DealDetails         `json:"dealDetails,omitempty"`
+	IsBuyboxWinner bool                 `json:"isBuyBoxWinner"`
+	LoyaltyPoints  *LoyaltyPoints       `json:"loyaltyPoints,omitempty"`
+	MerchantInfo   *OfferMerchantInfoV2 `json:"merchantInfo,omitempty"`
+	Price          *OfferPriceV2        `json:"price,omitempty"`
+	Type           string               `json:"type,omitempty"`
+	ViolatesMAP    bool                 `json:"violatesMAP"`
+}
+
+type DealDetails struct {
+	AccessType                        string `json:"accessType,omitempty"`
+	Badge                             string `json:"badge,omitempty"`
+	EarlyAccessDurationInMilliseconds int64  `json:"earlyAccessDurationInMilliseconds,omitempty"`
+	EndTime                           string `json:"endTime,omitempty"`
+	PercentClaimed                    int    `json:"percentClaimed,omitempty"`
+	StartTime                         string `json:"startTime,omitempty"`
+}
+
+type OfferMerchantInfoV2 struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type OfferPriceV2 struct {
+	Money        *Money          `json:"money,omitempty"`
+	PricePerUnit *Money          `json:"pricePerUnit,omitempty"`
+	SavingBasis  *SavingBasis    `json:"savingBasis,omitempty"`
+	Savings      *OfferSavingsV2 `json:"savings,omitempty"`
+}
+
+type OfferSavingsV2 struct {
+	Money      *Money `json:"money,omitempty"`
+	Percentage int    `json:"percentage"`
 }
 
 type Refinement struct {
-	Id          string
-	DisplayName string
-	Bins        []struct {
-		Id          string
-		DisplayName string
-	} `json:",omitempty"`
+	Id          string          `json:"id,omitempty"`
+	DisplayName string          `json:"displayName,omitempty"`
+	Bins        []RefinementBin `json:"bins,omitempty"`
+}
+
+type RefinementBin struct {
+	Id          string `json:"id,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
 }
 
 type Price struct {
-	DisplayAmount string
-	Amount        float64
-	Currency      string
+	DisplayAmount string  `json:"displayAmount,omitempty"`
+	Amount        float64 `json:"amount"`
+	Currency      string  `json:"currency,omitempty"`
 }
 
 type VariationDimension struct {
-	DisplayName string
+	DisplayName string `json:"displayName,omitempty"`
 	// Deprecated: not described in current Creators API documentation; retained for JSON compatibility.
-	Locale string `json:",omitempty"`
-	Name   string
-	Values []string
+	Locale string   `json:"locale,omitempty"`
+	Name   string   `json:"name,omitempty"`
+	Values []string `json:"values,omitempty"`
 }
 
 type Response struct {
-	Errors []struct {
-		Code    string
-		Message string
-	} `json:",omitempty"`
-	ItemsResult *struct {
-		Items []Item `json:",omitempty"`
-	} `json:"itemsResult,omitempty"`
-	SearchResult *struct {
-		Items             []Item `json:",omitempty"`
-		SearchRefinements *struct {
-			SearchIndex      *Refinement  `json:",omitempty"`
-			BrowseNode       *Refinement  `json:",omitempty"`
-			OtherRefinements []Refinement `json:",omitempty"`
-		} `json:",omitempty"`
-		SearchURL        string
-		TotalResultCount int
-	} `json:",omitempty"`
-	VariationsResult *struct {
-		Items            []Item `json:",omitempty"`
-		VariationSummary *struct {
-			PageCount      int
-			VariationCount int
-			Price          *struct {
-				HighestPrice *Price `json:",omitempty"`
-				LowestPrice  *Price `json:",omitempty"`
-			} `json:",omitempty"`
-			VariationDimensions []VariationDimension `json:",omitempty"`
-		} `json:",omitempty"`
-	} `json:",omitempty"`
-	BrowseNodesResult *struct {
-		BrowseNodes []*struct {
-			Ancestor *Ancestor `json:",omitempty"`
-			Children []*struct {
-				Id              string
-				DisplayName     string
-				ContextFreeName string
-			} `json:",omitempty"`
-			Id              string
-			DisplayName     string
-			ContextFreeName string
-			IsRoot          bool
-			// Deprecated: not described in current Creators API documentation; retained for JSON compatibility.
-			SalesRank *int `json:",omitempty"`
-		} `json:",omitempty"`
-	} `json:",omitempty"`
+	Errors            []ResponseError    `json:"errors,omitempty"`
+	ItemsResult       *ItemsResult       `json:"itemsResult,omitempty"`
+	SearchResult      *SearchResult      `json:"searchResult,omitempty"`
+	VariationsResult  *VariationsResult  `json:"variationsResult,omitempty"`
+	BrowseNodesResult *BrowseNodesResult `json:"browseNodesResult,omitempty"`
+}
+
+type ResponseError struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type ItemsResult struct {
+	Items []Item `json:"items,omitempty"`
+}
+
+type SearchResult struct {
+	Items             []Item             `json:"items,omitempty"`
+	SearchRefinements *SearchRefinements `json:"searchRefinements,omitempty"`
+	SearchURL         string             `json:"searchURL,omitempty"`
+	TotalResultCount  int                `json:"totalResultCount"`
+}
+
+type SearchRefinements struct {
+	SearchIndex      *Refinement  `json:"searchIndex,omitempty"`
+	BrowseNode       *Refinement  `json:"browseNode,omitempty"`
+	OtherRefinements []Refinement `json:"otherRefinements,omitempty"`
+}
+
+type VariationsResult struct {
+	Items            []Item            `json:"items,omitempty"`
+	VariationSummary *VariationSummary `json:"variationSummary,omitempty"`
+}
+
+type VariationSummary struct {
+	PageCount           int                  `json:"pageCount"`
+	VariationCount      int                  `json:"variationCount"`
+	Price               *VariationPrice      `json:"price,omitempty"`
+	VariationDimensions []VariationDimension `json:"variationDimensions,omitempty"`
+}
+
+type VariationPrice struct {
+	HighestPrice *Price `json:"highestPrice,omitempty"`
+	LowestPrice  *Price `json:"lowestPrice,omitempty"`
+}
+
+type BrowseNodesResult struct {
+	BrowseNodes []*BrowseNodeDetail `json:"browseNodes,omitempty"`
+}
+
+type BrowseNodeDetail struct {
+	Ancestor        *Ancestor          `json:"ancestor,omitempty"`
+	Children        []*BrowseNodeChild `json:"children,omitempty"`
+	Id              string             `json:"id,omitempty"`
+	DisplayName     string             `json:"displayName,omitempty"`
+	ContextFreeName string             `json:"contextFreeName,omitempty"`
+	IsRoot          bool               `json:"isRoot"`
+	// Deprecated: not described in current Creators API documentation; retained for JSON compatibility.
+	SalesRank *int `json:"salesRank,omitempty"`
+}
+
+type BrowseNodeChild struct {
+	Id              string `json:"id,omitempty"`
+	DisplayName     string `json:"displayName,omitempty"`
+	ContextFreeName string `json:"contextFreeName,omitempty"`
 }
 
 // DecodeResponse returns array of Response instance from byte buffer
@@ -1,6 +1,13 @@
 package entity
 
-import "testing"
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
 
 func TestDecodeResponseItemResultsOffersV2AndBrowseNodeInfo(t *testing.T) {
 	body := []byte(`{
@@ -204,3 +211,142 @@ func TestDecodeResponseVariationSummary(t *testing.T) {
 		t.Errorf("VariationDimensions[0].Locale = %q, want %q", got, want)
 	}
 }
+
+func TestNamedTypesRoundTrip(t *testing.T) {
+	count := 42
+	listing := OfferListingV2{
+		Availability:   &OfferAvailability{MaxOrderQuantity: 3, Type: "Now"},
+		IsBuyboxWinner: true,
+		MerchantInfo:   &OfferMerchantInfoV2{ID: "M1", Name: "Merchant"},
+		Price: &OfferPriceV2{
+			Money:   &Money{Amount: 12.5, Currency: "USD", DisplayAmount: "$12.50"},
+			Savings: &OfferSavingsV2{Money: &Money{Amount: 2.5}, Percentage: 17},
+		},
+		ViolatesMAP: true,
+	}
+	resp := &Response{
+		Errors: []ResponseError{{Code: "ItemNotAccessible", Message: "The ItemId B2 is not accessible."}},
+		ItemsResult: &ItemsResult{Items: []Item{{
+			ASIN:            "B1",
+			CustomerReviews: &CustomerReviews{Count: &count},
+			BrowseNodeInfo:  &BrowseNodeInfo{BrowseNodes: []BrowseNode{{Id: "123", WebsiteSalesRank: &WebsiteSalesRank{SalesRank: 7}}}},
+			Images:          &Images{Primary: &ImageSet{Large: &Image{URL: "https://example/l.jpg"}}},
+			ItemInfo: &ItemInfo{
+				ByLineInfo: &ByLineInfo{Contributors: []Contributor{{Name: "Author", Role: "Author"}}},
+				Title:      &GenInfo{DisplayValue: "Title"},
+			},
+			OffersV2: &OffersV2{Listings: &[]OfferListingV2{listing}},
+		}}},
+		BrowseNodesResult: &BrowseNodesResult{BrowseNodes: []*BrowseNodeDetail{{Id: "1", Children: []*BrowseNodeChild{{Id: "2"}}}}},
+	}
+
+	b, err := resp.JSON()
+	if err != nil {
+		t.Fatalf("JSON: %+v", err)
+	}
+	decoded, err := DecodeResponse(b)
+	if err != nil {
+		t.Fatalf("DecodeResponse: %+v", err)
+	}
+	if !reflect.DeepEqual(decoded, resp) {
+		t.Errorf("round trip = %v, want %v", decoded, resp)
+	}
+	if got := buyBoxAmount(&(*decoded.ItemsResult.Items[0].OffersV2.Listings)[0]); got != 12.5 {
+		t.Errorf("buyBoxAmount = %v, want 12.5", got)
+	}
+}
+
+// buyBoxAmount is a helper taking a single OffersV2 listing.
+func buyBoxAmount(l *OfferListingV2) float64 {
+	if l == nil || !l.IsBuyboxWinner || l.Price == nil || l.Price.Money == nil {
+		return 0
+	}
+	return l.Price.Money.Amount
+}
+
+func TestResponseJSONRoundTrip(t *testing.T) {
+	for _, name := range []string{"getitems.json", "searchitems.json", "getvariations.json", "getbrowsenodes.json"} {
+		t.Run(name, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("testdata", name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := DecodeResponse(body)
+			if err != nil {
+				t.Fatalf("DecodeResponse: %+v", err)
+			}
+			b, err := resp.JSON()
+			if err != nil {
+				t.Fatalf("JSON: %+v", err)
+			}
+			var want, got any
+			if err := json.Unmarshal(body, &want); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+			compareWire(t, "", want, got)
+		})
+	}
+}
+
+// compareWire checks that got, the re-encoded form of the wire value want,
+// is the same JSON tree: every key of want with the same value, and no key
+// of its own.
+func compareWire(t *testing.T, path string, want, got any) {
+	t.Helper()
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			t.Errorf("%s: %v, want an object", path, got)
+			return
+		}
+		for k := range g {
+			if _, ok := w[k]; !ok {
+				t.Errorf("%s.%s: key not in the response", path, k)
+			}
+		}
+		for k, v := range w {
+			if gv, ok := g[k]; ok {
+				compareWire(t, path+"."+k, v, gv)
+			} else {
+				t.Errorf("%s.%s: %v dropped", path, k, v)
+			}
+		}
+	case []any:
+		g, _ := got.([]any)
+		if len(g) != len(w) {
+			t.Errorf("%s: %d elements, want %d", path, len(g), len(w))
+			return
+		}
+		for i := range w {
+			compareWire(t, fmt.Sprintf("%s[%d]", path, i), w[i], g[i])
+		}
+	default:
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("%s: %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestResponseJSONSparse(t *testing.T) {
+	for _, body := range []string{
+		`{"itemsResult":{"items":[{"asin":"4873119618","itemInfo":{"byLineInfo":{"brand":{"displayValue":"O'Reilly"}}}}]}}`,
+		// zero values sent by the API are kept
+		`{"searchResult":{"items":[{"asin":"4873119618","browseNodeInfo":{"browseNodes":[{"id":"466298","displayName":"Books","isRoot":false}]},"offersV2":{"listings":[{"isBuyBoxWinner":false,"price":{"money":{"amount":0,"currency":"JPY"}},"violatesMAP":false}]}}],"totalResultCount":1}}`,
+	} {
+		resp, err := DecodeResponse([]byte(body))
+		if err != nil {
+			t.Fatalf("DecodeResponse: %+v", err)
+		}
+		b, err := resp.JSON()
+		if err != nil {
+			t.Fatalf("JSON: %+v", err)
+		}
+		if string(b) != body {
+			t.Errorf("JSON() = %s, want %s", b, body)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package entity
+
+import "strings"
+
+// ImageSize is the size of an image in Item.Images
+type ImageSize int
+
+const (
+	ImageSmall ImageSize = iota + 1
+	ImageMedium
+	ImageLarge
+	ImageHiRes
+)
+
+var imageSizeMap = map[ImageSize]string{
+	ImageSmall:  "Small",
+	ImageMedium: "Medium",
+	ImageLarge:  "Large",
+	ImageHiRes:  "HiRes",
+}
+
+// String is Stringer method
+func (s ImageSize) String() string {
+	if name, ok := imageSizeMap[s]; ok {
+		return name
+	}
+	return ""
+}
+
+// Title method returns ItemInfo.Title.DisplayValue
+func (i *Item) Title() (string, bool) {
+	if i == nil || i.ItemInfo == nil {
+		return "", false
+	}
+	return genInfoValue(i.ItemInfo.Title)
+}
+
+// Brand method returns ItemInfo.ByLineInfo.Brand.DisplayValue
+func (i *Item) Brand() (string, bool) {
+	if i == nil || i.ItemInfo == nil || i.ItemInfo.ByLineInfo == nil {
+		return "", false
+	}
+	return genInfoValue(i.ItemInfo.ByLineInfo.Brand)
+}
+
+// Contributors method returns contributors in ItemInfo.ByLineInfo with the
+// role (case-insensitive), or all contributors if role is empty.
+func (i *Item) Contributors(role string) ([]Contributor, bool) {
+	if i == nil || i.ItemInfo == nil || i.ItemInfo.ByLineInfo == nil {
+		return nil, false
+	}
+	list := []Contributor{}
+	for _, c := range i.ItemInfo.ByLineInfo.Contributors {
+		if len(role) == 0 || strings.EqualFold(c.Role, role) {
+			list = append(list, c)
+		}
+	}
+	if len(list) == 0 {
+		return nil, false
+	}
+	return list, true
+}
+
+// PrimaryImage method returns the primary image of the size
+func (i *Item) PrimaryImage(size ImageSize) (Image, bool) {
+	if i == nil || i.Images == nil || i.Images.Primary == nil {
+		return Image{}, false
+	}
+	var img *Image
+	switch size {
+	case ImageSmall:
+		img = i.Images.Primary.Small
+	case ImageMedium:
+		img = i.Images.Primary.Medium
+	case ImageLarge:
+		img = i.Images.Primary.Large
+	case ImageHiRes:
+		img = i.Images.Primary.HiRes
+	}
+	if img == nil || len(img.URL) == 0 {
+		return Image{}, false
+	}
+	return *img, true
+}
+
+// BuyBoxListing method returns the OffersV2 listing winning the buy box
+func (i *Item) BuyBoxListing() (OfferListingV2, bool) {
+	for _, l := range i.offerListingsV2() {
+		if l.IsBuyboxWinner {
+			return l, true
+		}
+	}
+	return OfferListingV2{}, false
+}
+
+// LowestPrice method returns the lowest price (Price.Money) of OffersV2 listings
+func (i *Item) LowestPrice() (Money, bool) {
+	var lowest *Money
+	for _, l := range i.offerListingsV2() {
+		if l.Price == nil || l.Price.Money == nil {
+			continue
+		}
+		if lowest == nil || l.Price.Money.Amount < lowest.Amount {
+			lowest = l.Price.Money
+		}
+	}
+	if lowest == nil {
+		return Money{}, false
+	}
+	return *lowest, true
+}
+
+// ISBN13 method returns the ISBN-13 of the item. It is taken from
+// ExternalIds.ISBNs (ISBN-10 values are converted), or else from an EAN
+// with the 978 or 979 prefix.
+func (i *Item) ISBN13() (string, bool) {
+	ids := i.externalIds()
+	if ids == nil {
+		return "", false
+	}
+	if ids.ISBNs != nil {
+		for _, v := range ids.ISBNs.DisplayValues {
+			if isbn, ok := toISBN13(v); ok {
+				return isbn, true
+			}
+		}
+	}
+	if ids.EANs != nil {
+		for _, v := range ids.EANs.DisplayValues {
+			if ean := normalizeCode(v); len(ean) == 13 && isDigits(ean) && (strings.HasPrefix(ean, "978") || strings.HasPrefix(ean, "979")) {
+				return ean, true
+			}
+		}
+	}
+	return "", false
+}
+
+// EAN method returns the first value of ExternalIds.EANs
+func (i *Item) EAN() (string, bool) {
+	ids := i.externalIds()
+	if ids == nil || ids.EANs == nil {
+		return "", false
+	}
+	for _, v := range ids.EANs.DisplayValues {
+		if len(v) > 0 {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// StarRating method returns CustomerReviews.StarRating.Value
+func (i *Item) StarRating() (float64, bool) {
+	if i == nil || i.CustomerReviews == nil || i.CustomerReviews.StarRating == nil || i.CustomerReviews.StarRating.Value == nil {
+		return 0, false
+	}
+	return *i.CustomerReviews.StarRating.Value, true
+}
+
+// ReviewCount method returns CustomerReviews.Count
+func (i *Item) ReviewCount() (int, bool) {
+	if i == nil || i.CustomerReviews == nil || i.CustomerReviews.Count == nil {
+		return 0, false
+	}
+	return *i.CustomerReviews.Count, true
+}
+
+// SalesRank method returns the website sales rank of the item, taken from
+// the first browse node reporting one in BrowseNodeInfo.
+func (i *Item) SalesRank() (int, bool) {
+	if i == nil || i.BrowseNodeInfo == nil {
+		return 0, false
+	}
+	for _, n := range i.BrowseNodeInfo.BrowseNodes {
+		if n.WebsiteSalesRank != nil && n.WebsiteSalesRank.SalesRank > 0 {
+			return n.WebsiteSalesRank.SalesRank, true
+		}
+	}
+	for _, n := range i.BrowseNodeInfo.BrowseNodes {
+		if n.SalesRank != nil && *n.SalesRank > 0 {
+			return *n.SalesRank, true
+		}
+	}
+	return 0, false
+}
+
+func (i *Item) offerListingsV2() []OfferListingV2 {
+	if i == nil || i.OffersV2 == nil || i.OffersV2.Listings == nil {
+		return nil
+	}
+	return *i.OffersV2.Listings
+}
+
+func (i *Item) externalIds() *ExternalIds {
+	if i == nil || i.ItemInfo == nil {
+		return nil
+	}
+	return i.ItemInfo.ExternalIds
+}
+
+func genInfoValue(info *GenInfo) (string, bool) {
+	if info == nil || len(info.DisplayValue) == 0 {
+		return "", false
+	}
+	return info.DisplayValue, true
+}
+
+// normalizeCode removes hyphens and spaces from ISBN/EAN code
+func normalizeCode(s string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(s)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+// toISBN13 returns ISBN-13 code from ISBN-13 or ISBN-10 code
+func toISBN13(s string) (string, bool) {
+	s = normalizeCode(s)
+	switch {
+	case len(s) == 13 && isDigits(s):
+		return s, true
+	case len(s) == 10 && isDigits(s[:9]) && (isDigits(s[9:]) || s[9] == 'X' || s[9] == 'x'):
+		code := "978" + s[:9]
+		sum := 0
+		for n, r := range code {
+			d := int(r - '0')
+			if n%2 == 1 {
+				d *= 3
+			}
+			sum += d
+		}
+		return code + string(rune('0'+(10-sum%10)%10)), true
+	}
+	return "", false
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
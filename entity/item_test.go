@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestItemAccessors(t *testing.T) {
+	body := []byte(`{
+  "itemsResult": {
+    "items": [
+      {
+        "asin": "4621300253",
+        "customerReviews": {"count": 12, "starRating": {"value": 4.5}},
+        "browseNodeInfo": {
+          "browseNodes": [
+            {"id": "1", "displayName": "Math"},
+            {"id": "2", "displayName": "Books", "websiteSalesRank": {"salesRank": 345}}
+          ]
+        },
+        "images": {
+          "primary": {
+            "small": {"url": "https://example/s.jpg", "height": 75, "width": 75},
+            "large": {"url": "https://example/l.jpg", "height": 500, "width": 500}
+          }
+        },
+        "itemInfo": {
+          "title": {"displayValue": "数学ガール"},
+          "byLineInfo": {
+            "brand": {"displayValue": "SB Creative"},
+            "contributors": [
+              {"name": "結城 浩", "role": "著"},
+              {"name": "Translator", "role": "Translator"}
+            ]
+          },
+          "externalIds": {
+            "eans": {"displayValues": ["9784797341379"]},
+            "isbns": {"displayValues": ["4-7973-4137-8"]}
+          }
+        },
+        "offersV2": {
+          "listings": [
+            {"isBuyBoxWinner": false, "price": {"money": {"amount": 1800, "currency": "JPY"}}},
+            {"isBuyBoxWinner": true, "price": {"money": {"amount": 1900, "currency": "JPY"}}, "merchantInfo": {"id": "M1"}},
+            {"isBuyBoxWinner": false}
+          ]
+        }
+      }
+    ]
+  }
+}`)
+	resp, err := DecodeResponse(body)
+	if err != nil {
+		t.Fatalf("DecodeResponse: %+v", err)
+	}
+	item := &resp.ItemsResult.Items[0]
+
+	if v, ok := item.Title(); !ok || v != "数学ガール" {
+		t.Errorf("Title() = %q, %v", v, ok)
+	}
+	if v, ok := item.Brand(); !ok || v != "SB Creative" {
+		t.Errorf("Brand() = %q, %v", v, ok)
+	}
+	if v, ok := item.Contributors("translator"); !ok || len(v) != 1 || v[0].Name != "Translator" {
+		t.Errorf("Contributors(translator) = %v, %v", v, ok)
+	}
+	if v, ok := item.Contributors(""); !ok || len(v) != 2 {
+		t.Errorf("Contributors() = %v, %v", v, ok)
+	}
+	if v, ok := item.Contributors("Editor"); ok || v != nil {
+		t.Errorf("Contributors(Editor) = %v, %v", v, ok)
+	}
+	if v, ok := item.PrimaryImage(ImageLarge); !ok || v.URL != "https://example/l.jpg" || v.Width != 500 {
+		t.Errorf("PrimaryImage(Large) = %v, %v", v, ok)
+	}
+	if v, ok := item.PrimaryImage(ImageMedium); ok || v != (Image{}) {
+		t.Errorf("PrimaryImage(Medium) = %v, %v", v, ok)
+	}
+	if v, ok := item.BuyBoxListing(); !ok || v.MerchantInfo == nil || v.MerchantInfo.ID != "M1" {
+		t.Errorf("BuyBoxListing() = %v, %v", v, ok)
+	}
+	if v, ok := item.LowestPrice(); !ok || v.Amount != 1800 || v.Currency != "JPY" {
+		t.Errorf("LowestPrice() = %v, %v", v, ok)
+	}
+	if v, ok := item.ISBN13(); !ok || v != "9784797341379" {
+		t.Errorf("ISBN13() = %q, %v", v, ok)
+	}
+	if v, ok := item.EAN(); !ok || v != "9784797341379" {
+		t.Errorf("EAN() = %q, %v", v, ok)
+	}
+	if v, ok := item.StarRating(); !ok || v != 4.5 {
+		t.Errorf("StarRating() = %v, %v", v, ok)
+	}
+	if v, ok := item.ReviewCount(); !ok || v != 12 {
+		t.Errorf("ReviewCount() = %v, %v", v, ok)
+	}
+	if v, ok := item.SalesRank(); !ok || v != 345 {
+		t.Errorf("SalesRank() = %v, %v", v, ok)
+	}
+}
+
+func TestItemAccessorsZero(t *testing.T) {
+	for _, item := range []*Item{nil, {}, {ItemInfo: &ItemInfo{ByLineInfo: &ByLineInfo{}, ExternalIds: &ExternalIds{}}, OffersV2: &OffersV2{}}} {
+		if v, ok := item.Title(); ok || v != "" {
+			t.Errorf("Title() = %q, %v", v, ok)
+		}
+		if v, ok := item.Brand(); ok || v != "" {
+			t.Errorf("Brand() = %q, %v", v, ok)
+		}
+		if v, ok := item.Contributors(""); ok || v != nil {
+			t.Errorf("Contributors() = %v, %v", v, ok)
+		}
+		if v, ok := item.PrimaryImage(ImageSmall); ok || v != (Image{}) {
+			t.Errorf("PrimaryImage() = %v, %v", v, ok)
+		}
+		if v, ok := item.BuyBoxListing(); ok || !reflect.DeepEqual(v, OfferListingV2{}) {
+			t.Errorf("BuyBoxListing() = %v, %v", v, ok)
+		}
+		if v, ok := item.LowestPrice(); ok || v != (Money{}) {
+			t.Errorf("LowestPrice() = %v, %v", v, ok)
+		}
+		if v, ok := item.ISBN13(); ok || v != "" {
+			t.Errorf("ISBN13() = %q, %v", v, ok)
+		}
+		if v, ok := item.EAN(); ok || v != "" {
+			t.Errorf("EAN() = %q, %v", v, ok)
+		}
+		if v, ok := item.StarRating(); ok || v != 0 {
+			t.Errorf("StarRating() = %v, %v", v, ok)
+		}
+		if v, ok := item.ReviewCount(); ok || v != 0 {
+			t.Errorf("ReviewCount() = %v, %v", v, ok)
+		}
+		if v, ok := item.SalesRank(); ok || v != 0 {
+			t.Errorf("SalesRank() = %v, %v", v, ok)
+		}
+	}
+}
+
+func TestToISBN13(t *testing.T) {
+	testCases := []struct {
+		in   string
+		isbn string
+		ok   bool
+	}{
+		{in: "0-306-40615-2", isbn: "9780306406157", ok: true},
+		{in: "4797341378", isbn: "9784797341379", ok: true},
+		{in: "080442957X", isbn: "9780804429573", ok: true},
+		{in: "978-4-7973-4137-9", isbn: "9784797341379", ok: true},
+		{in: "B00EXAMPLE", isbn: "", ok: false},
+	}
+	for _, tc := range testCases {
+		isbn, ok := toISBN13(tc.in)
+		if isbn != tc.isbn || ok != tc.ok {
+			t.Errorf("toISBN13(%q) = %q, %v, want %q, %v", tc.in, isbn, ok, tc.isbn, tc.ok)
+		}
+	}
+}
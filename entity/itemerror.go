@@ -0,0 +1,152 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ItemError is an error in Response.Errors, with the ASIN or browse node
+// ID it refers to extracted from the message.
+type ItemError struct {
+	Code         string
+	Message      string
+	ASIN         string // ASIN the error refers to, if any
+	BrowseNodeID string // browse node ID the error refers to, if any
+}
+
+// Error method returns error message.
+// This method is a implementation of error interface.
+func (e *ItemError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// ID method returns the ASIN or browse node ID the error refers to.
+func (e *ItemError) ID() string {
+	if e == nil {
+		return ""
+	}
+	if len(e.ASIN) > 0 {
+		return e.ASIN
+	}
+	return e.BrowseNodeID
+}
+
+var (
+	// e.g. "The value [B00000000X] provided in the request for ItemIds is invalid."
+	// or "The ItemId B00000000X is not accessible through the Creators API."
+	asinPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\[([0-9A-Z]{10})\][^\[]*\bItemIds?\b`),
+		regexp.MustCompile(`\b(?:ItemId|ASIN)\s+([0-9A-Z]{10})\b`),
+	}
+	// e.g. "The BrowseNodeId 123 provided in the request is invalid."
+	browseNodePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\[([0-9]+)\][^\[]*\bBrowseNodeIds?\b`),
+		regexp.MustCompile(`\bBrowseNodeId\s+([0-9]+)\b`),
+	}
+)
+
+// ItemError method returns ItemError instance from ResponseError
+func (e ResponseError) ItemError() *ItemError {
+	ie := &ItemError{Code: e.Code, Message: e.Message}
+	if id, ok := findID(e.Message, asinPatterns); ok {
+		ie.ASIN = id
+	} else if id, ok := findID(e.Message, browseNodePatterns); ok {
+		ie.BrowseNodeID = id
+	}
+	return ie
+}
+
+func findID(msg string, patterns []*regexp.Regexp) (string, bool) {
+	for _, p := range patterns {
+		if m := p.FindStringSubmatch(msg); m != nil {
+			return m[1], true
+		}
+	}
+	return "", false
+}
+
+// ItemErrors method returns Errors as ItemError instances
+func (r *Response) ItemErrors() []*ItemError {
+	if r == nil {
+		return nil
+	}
+	list := make([]*ItemError, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		list = append(list, e.ItemError())
+	}
+	return list
+}
+
+// FailedIDs method returns the ASINs and browse node IDs reported in
+// Errors, in the order of Errors and without duplicates.
+func (r *Response) FailedIDs() []string {
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, e := range r.ItemErrors() {
+		if id := e.ID(); len(id) > 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Succeeded method returns the ASINs of the items and the IDs of the
+// browse nodes in the response, in response order and without duplicates.
+func (r *Response) Succeeded() []string {
+	ids := []string{}
+	if r == nil {
+		return ids
+	}
+	seen := map[string]bool{}
+	add := func(id string) {
+		if len(id) > 0 && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, item := range r.itemsOf() {
+		add(item.ASIN)
+	}
+	if r.BrowseNodesResult != nil {
+		for _, n := range r.BrowseNodesResult.BrowseNodes {
+			if n != nil {
+				add(n.Id)
+			}
+		}
+	}
+	return ids
+}
+
+// itemsOf returns the items of all results in the response.
+func (r *Response) itemsOf() []Item {
+	items := []Item{}
+	if r.ItemsResult != nil {
+		items = append(items, r.ItemsResult.Items...)
+	}
+	if r.SearchResult != nil {
+		items = append(items, r.SearchResult.Items...)
+	}
+	if r.VariationsResult != nil {
+		items = append(items, r.VariationsResult.Items...)
+	}
+	return items
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestItemError(t *testing.T) {
+	testCases := []struct {
+		msg          string
+		asin         string
+		browseNodeID string
+	}{
+		{msg: "The ItemId B00000000X is not accessible through the Creators API.", asin: "B00000000X"},
+		{msg: "The value [4797341378] provided in the request for ItemIds is invalid.", asin: "4797341378"},
+		{msg: "The BrowseNodeId 3045 provided in the request is invalid.", browseNodeID: "3045"},
+		{msg: "The value [3045] provided in the request for BrowseNodeIds is invalid.", browseNodeID: "3045"},
+		{msg: "The ItemId provided in the request is invalid."},
+		{msg: "Request has been throttled."},
+	}
+	for _, tc := range testCases {
+		ie := ResponseError{Code: "InvalidParameterValue", Message: tc.msg}.ItemError()
+		if ie.ASIN != tc.asin || ie.BrowseNodeID != tc.browseNodeID {
+			t.Errorf("ItemError(%q) = %+v, want ASIN %q, BrowseNodeID %q", tc.msg, ie, tc.asin, tc.browseNodeID)
+		}
+		if got, want := ie.Error(), "InvalidParameterValue: "+tc.msg; got != want {
+			t.Errorf("Error() = %q, want %q", got, want)
+		}
+	}
+	var err error = ResponseError{Code: "ItemNotAccessible", Message: "The ItemId B00000000X is not accessible through the Creators API."}.ItemError()
+	var ie *ItemError
+	if !errors.As(err, &ie) || ie.ID() != "B00000000X" {
+		t.Errorf("errors.As = %+v", ie)
+	}
+}
+
+func TestResponseFailedIDsAndSucceeded(t *testing.T) {
+	body := []byte(`{
+  "errors": [
+    {"code": "ItemNotAccessible", "message": "The ItemId B00000000X is not accessible through the Creators API."},
+    {"code": "InvalidParameterValue", "message": "The value [B00000000Y] provided in the request for ItemIds is invalid."},
+    {"code": "ItemNotAccessible", "message": "The ItemId B00000000X is not accessible through the Creators API."},
+    {"code": "TooManyRequests", "message": "Request has been throttled."}
+  ],
+  "itemsResult": {"items": [{"asin": "B000000002"}, {"asin": "B000000001"}]}
+}`)
+	resp, err := DecodeResponse(body)
+	if err != nil {
+		t.Fatalf("DecodeResponse: %+v", err)
+	}
+	if got, want := resp.FailedIDs(), []string{"B00000000X", "B00000000Y"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FailedIDs() = %v, want %v", got, want)
+	}
+	if got, want := resp.Succeeded(), []string{"B000000002", "B000000001"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Succeeded() = %v, want %v", got, want)
+	}
+	if got := len(resp.ItemErrors()); got != 4 {
+		t.Errorf("len(ItemErrors()) = %d, want 4", got)
+	}
+
+	var nilResp *Response
+	if len(nilResp.FailedIDs()) != 0 || len(nilResp.Succeeded()) != 0 || nilResp.ItemErrors() != nil {
+		t.Error("nil Response should have no IDs")
+	}
+}
+
+func TestResponseSucceededBrowseNodes(t *testing.T) {
+	resp := &Response{
+		Errors:            []ResponseError{{Code: "InvalidParameterValue", Message: "The BrowseNodeId 999 provided in the request is invalid."}},
+		BrowseNodesResult: &BrowseNodesResult{BrowseNodes: []*BrowseNodeDetail{{Id: "3045"}, nil, {Id: "3040"}}},
+	}
+	if got, want := resp.Succeeded(), []string{"3045", "3040"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Succeeded() = %v, want %v", got, want)
+	}
+	if got, want := resp.FailedIDs(), []string{"999"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FailedIDs() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,210 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/goark/errs"
+)
+
+// ErrUnexpectedToken is returned by ItemDecoder for JSON that is not shaped
+// like a response.
+var ErrUnexpectedToken = errors.New("unexpected JSON token")
+
+// resultKeys are the members of Response holding an items array.
+var resultKeys = []string{"itemsResult", "searchResult", "variationsResult"}
+
+// ItemDecoder reads the items of a response one at a time, without
+// materialising the whole response.
+type ItemDecoder struct {
+	dec      *json.Decoder
+	started  bool
+	inResult bool
+	inItems  bool
+	done     bool
+	err      error
+	errors   []ResponseError
+}
+
+// NewItemDecoder returns ItemDecoder instance reading a response from r.
+// It yields the elements of itemsResult.items, searchResult.items and
+// variationsResult.items; other members are skipped, except errors (see
+// ItemDecoder.Errors).
+func NewItemDecoder(r io.Reader) *ItemDecoder {
+	return &ItemDecoder{dec: json.NewDecoder(r)}
+}
+
+// Next method returns the next item of the response. It returns io.EOF
+// after the last item, and the same error again after a failure.
+func (d *ItemDecoder) Next() (*Item, error) {
+	if d == nil || d.dec == nil {
+		return nil, io.EOF
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	for !d.done {
+		switch {
+		case d.inItems:
+			if d.dec.More() {
+				item := &Item{}
+				if err := d.dec.Decode(item); err != nil {
+					return nil, d.fail(err)
+				}
+				return item, nil
+			}
+			if err := d.expectDelim(']'); err != nil {
+				return nil, err
+			}
+			d.inItems = false
+		case d.inResult:
+			if !d.dec.More() {
+				if err := d.expectDelim('}'); err != nil {
+					return nil, err
+				}
+				d.inResult = false
+				continue
+			}
+			key, err := d.key()
+			if err != nil {
+				return nil, err
+			}
+			if strings.EqualFold(key, "items") {
+				if d.inItems, err = d.open('['); err != nil {
+					return nil, err
+				}
+			} else if err := d.skip(); err != nil {
+				return nil, err
+			}
+		case !d.started:
+			if err := d.expectDelim('{'); err != nil {
+				return nil, err
+			}
+			d.started = true
+		default:
+			if !d.dec.More() {
+				if err := d.expectDelim('}'); err != nil {
+					return nil, err
+				}
+				d.done = true
+				continue
+			}
+			key, err := d.key()
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case isResultKey(key):
+				if d.inResult, err = d.open('{'); err != nil {
+					return nil, err
+				}
+			case strings.EqualFold(key, "errors"):
+				errList := []ResponseError{}
+				if err := d.dec.Decode(&errList); err != nil {
+					return nil, d.fail(err)
+				}
+				d.errors = append(d.errors, errList...)
+			default:
+				if err := d.skip(); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return nil, io.EOF
+}
+
+// Errors method returns the errors member of the response read so far.
+// It is complete once Next has returned io.EOF.
+func (d *ItemDecoder) Errors() []ResponseError {
+	if d == nil {
+		return nil
+	}
+	return d.errors
+}
+
+func isResultKey(key string) bool {
+	for _, k := range resultKeys {
+		if strings.EqualFold(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// key reads an object key.
+func (d *ItemDecoder) key() (string, error) {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return "", d.fail(err)
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", d.fail(errs.Wrap(ErrUnexpectedToken, errs.WithContext("token", tok)))
+	}
+	return key, nil
+}
+
+// open reads the start of an array or object, or null. It returns false
+// for null.
+func (d *ItemDecoder) open(delim json.Delim) (bool, error) {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return false, d.fail(err)
+	}
+	if tok == nil {
+		return false, nil
+	}
+	if tok != delim {
+		return false, d.fail(errs.Wrap(ErrUnexpectedToken, errs.WithContext("token", tok), errs.WithContext("want", delim.String())))
+	}
+	return true, nil
+}
+
+// expectDelim reads delim.
+func (d *ItemDecoder) expectDelim(delim json.Delim) error {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return d.fail(err)
+	}
+	if tok != delim {
+		return d.fail(errs.Wrap(ErrUnexpectedToken, errs.WithContext("token", tok), errs.WithContext("want", delim.String())))
+	}
+	return nil
+}
+
+// skip reads and discards the next value.
+func (d *ItemDecoder) skip() error {
+	var raw json.RawMessage
+	if err := d.dec.Decode(&raw); err != nil {
+		return d.fail(err)
+	}
+	return nil
+}
+
+// fail stops the decoder and returns err.
+func (d *ItemDecoder) fail(err error) error {
+	d.done = true
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	d.err = errs.Wrap(err, errs.WithContext("offset", d.dec.InputOffset()))
+	return d.err
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
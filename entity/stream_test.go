@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readItems reads all items from d.
+func readItems(d *ItemDecoder) ([]Item, error) {
+	items := []Item{}
+	for {
+		item, err := d.Next()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+		if err != nil {
+			return items, err
+		}
+		items = append(items, *item)
+	}
+}
+
+func TestItemDecoderFixtures(t *testing.T) {
+	for _, name := range []string{"getitems.json", "searchitems.json", "getvariations.json", "getbrowsenodes.json"} {
+		t.Run(name, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("testdata", name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := DecodeResponse(body)
+			if err != nil {
+				t.Fatalf("DecodeResponse: %+v", err)
+			}
+			want := []Item{}
+			if resp.ItemsResult != nil {
+				want = append(want, resp.ItemsResult.Items...)
+			}
+			if resp.SearchResult != nil {
+				want = append(want, resp.SearchResult.Items...)
+			}
+			if resp.VariationsResult != nil {
+				want = append(want, resp.VariationsResult.Items...)
+			}
+
+			d := NewItemDecoder(bytes.NewReader(body))
+			got, err := readItems(d)
+			if err != nil {
+				t.Fatalf("Next: %+v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("items = %+v, want %+v", got, want)
+			}
+			if len(d.Errors()) != len(resp.Errors) || (len(resp.Errors) > 0 && !reflect.DeepEqual(d.Errors(), resp.Errors)) {
+				t.Errorf("Errors() = %+v, want %+v", d.Errors(), resp.Errors)
+			}
+		})
+	}
+}
+
+func TestItemDecoderLarge(t *testing.T) {
+	var buf strings.Builder
+	buf.WriteString(`{"errors":[{"code":"C1","message":"M1"}],"searchResult":{"totalResultCount":1000,"searchRefinements":{"searchIndex":{"id":"All"}},"items":[`)
+	for i := range 1000 {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		fmt.Fprintf(&buf, `{"asin":"A%04d","itemInfo":{"title":{"displayValue":"Title %d"}}}`, i, i)
+	}
+	buf.WriteString(`],"searchURL":"https://example"},"itemsResult":null,"requestMetadata":{"x":[1,2]}}`)
+
+	d := NewItemDecoder(strings.NewReader(buf.String()))
+	items, err := readItems(d)
+	if err != nil {
+		t.Fatalf("Next: %+v", err)
+	}
+	if got := len(items); got != 1000 {
+		t.Fatalf("len(items) = %d, want 1000", got)
+	}
+	if title, _ := items[999].Title(); items[999].ASIN != "A0999" || title != "Title 999" {
+		t.Errorf("items[999] = %+v", items[999])
+	}
+	if errList := d.Errors(); len(errList) != 1 || errList[0].Code != "C1" {
+		t.Errorf("Errors() = %+v", errList)
+	}
+	if _, err := d.Next(); !errors.Is(err, io.EOF) {
+		t.Errorf("Next after end = %v, want io.EOF", err)
+	}
+}
+
+func TestItemDecoderErrors(t *testing.T) {
+	testCases := []struct {
+		body string
+		err  error
+	}{
+		{body: `[]`, err: ErrUnexpectedToken},
+		{body: `{"itemsResult":{"items":{}}}`, err: ErrUnexpectedToken},
+		{body: `{"itemsResult":{"items":[{"asin":"A1"},`},
+		{body: ``, err: io.ErrUnexpectedEOF},
+	}
+	for _, tc := range testCases {
+		d := NewItemDecoder(strings.NewReader(tc.body))
+		_, err := readItems(d)
+		if err == nil || (tc.err != nil && !errors.Is(err, tc.err)) {
+			t.Errorf("readItems(%q) error = %v, want %v", tc.body, err, tc.err)
+		}
+		if _, again := d.Next(); again != err {
+			t.Errorf("Next after failure = %v, want %v", again, err)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/goark/errs"
+)
+
+// DecodeReport is a report of DecodeResponseStrict function
+type DecodeReport struct {
+	// UnknownPaths are the JSON paths in the response that have no
+	// counterpart in Response, sorted and without duplicates. Array
+	// elements are written as "[]", e.g. "itemsResult.items[].newField".
+	UnknownPaths []string
+}
+
+// HasUnknown method returns true if the response has unknown JSON paths.
+func (r *DecodeReport) HasUnknown() bool {
+	return r != nil && len(r.UnknownPaths) > 0
+}
+
+// DecodeResponseStrict returns Response instance from byte buffer like
+// DecodeResponse function, and reports JSON paths that are silently
+// dropped by decoding. Unknown paths are not an error; use them to detect
+// changes of the Creators API schema.
+func DecodeResponseStrict(b []byte) (*Response, *DecodeReport, error) {
+	rsp, err := DecodeResponse(b)
+	if err != nil {
+		return rsp, nil, err
+	}
+	var raw any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return rsp, nil, errs.Wrap(err, errs.WithContext("JSON", string(b)))
+	}
+	found := map[string]bool{}
+	unknownPaths(raw, reflect.TypeOf(rsp).Elem(), "", found)
+	report := &DecodeReport{UnknownPaths: make([]string, 0, len(found))}
+	for path := range found {
+		report.UnknownPaths = append(report.UnknownPaths, path)
+	}
+	sort.Strings(report.UnknownPaths)
+	return rsp, report, nil
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownPaths walks JSON value v decoded into type t and adds JSON paths
+// not matching any field of t to found.
+func unknownPaths(v any, t reflect.Type, path string, found map[string]bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Implements(unmarshalerType) || reflect.PointerTo(t).Implements(unmarshalerType) {
+		return
+	}
+	switch value := v.(type) {
+	case map[string]any:
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		fields := jsonFields(t)
+		for key, elem := range value {
+			ft, ok := lookupField(fields, key)
+			if !ok {
+				found[joinPath(path, key)] = true
+				continue
+			}
+			unknownPaths(elem, ft, joinPath(path, key), found)
+		}
+	case []any:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+		for _, elem := range value {
+			unknownPaths(elem, t.Elem(), path+"[]", found)
+		}
+	}
+}
+
+// jsonFields returns the types of the fields of struct type t by JSON key,
+// including the fields promoted from embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && len(name) == 0 {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if len(name) == 0 {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// lookupField finds a field by JSON key the way encoding/json does: an exact
+// match first, then a case-insensitive one.
+func lookupField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if t, ok := fields[key]; ok {
+		return t, true
+	}
+	for name, t := range fields {
+		if strings.EqualFold(name, key) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func joinPath(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
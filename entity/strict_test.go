@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeResponseStrict(t *testing.T) {
+	body := []byte(`{
+  "itemsResult": {
+    "items": [
+      {
+        "ASIN": "A1",
+        "newTopLevel": 1,
+        "itemInfo": {"title": {"displayValue": "T", "script": "Latn"}},
+        "offersV2": {"listings": [{"price": {"money": {"amount": 1}, "taxIncluded": true}}, {"price": {"taxIncluded": false}}]},
+        "images": {"primary": {"xLarge": {"url": "https://example/x.jpg"}}}
+      }
+    ]
+  },
+  "errors": [{"code": "C", "message": "M", "details": {"id": "1"}}],
+  "requestMetadata": null
+}`)
+	rsp, report, err := DecodeResponseStrict(body)
+	if err != nil {
+		t.Fatalf("DecodeResponseStrict: %+v", err)
+	}
+	if got := rsp.ItemsResult.Items[0].ASIN; got != "A1" {
+		t.Errorf("ASIN = %q, want A1", got)
+	}
+	want := []string{
+		"errors[].details",
+		"itemsResult.items[].images.primary.xLarge",
+		"itemsResult.items[].itemInfo.title.script",
+		"itemsResult.items[].newTopLevel",
+		"itemsResult.items[].offersV2.listings[].price.taxIncluded",
+		"requestMetadata",
+	}
+	if !report.HasUnknown() || !reflect.DeepEqual(report.UnknownPaths, want) {
+		t.Errorf("UnknownPaths = %v, want %v", report.UnknownPaths, want)
+	}
+}
+
+func TestDecodeResponseStrictFixtures(t *testing.T) {
+	for _, name := range []string{"getitems.json", "searchitems.json", "getvariations.json", "getbrowsenodes.json"} {
+		body, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, report, err := DecodeResponseStrict(body)
+		if err != nil {
+			t.Fatalf("DecodeResponseStrict(%s): %+v", name, err)
+		}
+		if report.HasUnknown() {
+			t.Errorf("DecodeResponseStrict(%s) unknown paths = %v", name, report.UnknownPaths)
+		}
+	}
+}
+
+func TestDecodeResponseStrictError(t *testing.T) {
+	if _, report, err := DecodeResponseStrict([]byte(`{"itemsResult":`)); err == nil || report != nil {
+		t.Errorf("DecodeResponseStrict() = %v, %v, want error", report, err)
+	}
+}
@@ -6,15 +6,25 @@ import "fmt"
 type Error int
 
 const (
-	ErrNullPointer Error = iota + 1 //Null reference instance
-	ErrHTTPStatus                   //Bad HTTP status
-	ErrNoData                       //No response data
+	ErrNullPointer       Error = iota + 1 //Null reference instance
+	ErrHTTPStatus                         //Bad HTTP status
+	ErrNoData                             //No response data
+	ErrTooManyRequests                    //Request throttled by the Creators API
+	ErrInvalidPartnerTag                  //Invalid partner (associate) tag
+	ErrItemNotAccessible                  //Item not accessible through the Creators API
+	ErrUnauthorized                       //Unauthorized request (invalid or expired token)
+	ErrInvalidQuery                       //Invalid query (rejected filter or constraint violation)
 )
 
 var errMessages = map[Error]string{
-	ErrNullPointer: "Null reference instance",
-	ErrHTTPStatus:  "Bad HTTP status",
-	ErrNoData:      "No response data",
+	ErrNullPointer:       "Null reference instance",
+	ErrHTTPStatus:        "Bad HTTP status",
+	ErrNoData:            "No response data",
+	ErrTooManyRequests:   "Too many requests",
+	ErrInvalidPartnerTag: "Invalid partner tag",
+	ErrItemNotAccessible: "Item not accessible",
+	ErrUnauthorized:      "Unauthorized request",
+	ErrInvalidQuery:      "Invalid query",
 }
 
 //Error method returns error message.
@@ -14,7 +14,12 @@ func TestError(t *testing.T) {
 		{err: ErrNullPointer, str: "Null reference instance"},
 		{err: ErrHTTPStatus, str: "Bad HTTP status"},
 		{err: ErrNoData, str: "No response data"},
-		{err: Error(4), str: "unknown error (4)"},
+		{err: ErrTooManyRequests, str: "Too many requests"},
+		{err: ErrInvalidPartnerTag, str: "Invalid partner tag"},
+		{err: ErrItemNotAccessible, str: "Item not accessible"},
+		{err: ErrUnauthorized, str: "Unauthorized request"},
+		{err: ErrInvalidQuery, str: "Invalid query"},
+		{err: Error(9), str: "unknown error (9)"},
 	}
 
 	for _, tc := range testCases {
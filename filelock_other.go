@@ -0,0 +1,31 @@
+//go:build !unix
+
+package paapi5
+
+import "os"
+
+// tryLockFile is a no-op on platforms without flock(2). Cache entries are
+// still replaced atomically, so the worst case is a redundant token refresh.
+func tryLockFile(f *os.File) (bool, error) {
+	return true, nil
+}
+
+// unlockFile is a no-op on platforms without flock(2).
+func unlockFile(f *os.File) error {
+	return nil
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
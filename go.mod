@@ -2,7 +2,4 @@ module github.com/goark/pa-api
 
 go 1.25.10
 
-require (
-	github.com/goark/errs v1.3.2
-	github.com/goark/fetch v0.5.0
-)
+require github.com/goark/errs v1.3.2
@@ -0,0 +1,146 @@
+package paapi5
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// maxDebugBodyBytes caps how much of a request payload or response body is
+// written to a debug record.
+const maxDebugBodyBytes = 64 * 1024
+
+// WithLogger function returns a ClientOptFunc that emits structured records
+// to logger:
+//
+//   - one record per HTTP attempt with the operation, marketplace, payload
+//     size, status and latency (Info on success, Warn on failure, with the
+//     API error code and request ID);
+//   - retry waits (Info) and OAuth2 token refreshes, cache hits and
+//     invalidations of the built-in token manager;
+//   - request payloads and response bodies, at Debug level only.
+//
+// Access tokens, credential secrets and the Authorization header are never
+// logged; credential IDs are masked.
+func WithLogger(logger *slog.Logger) ClientOptFunc {
+	return func(c *client) {
+		if c != nil && logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// redacted is a string that is masked in log records, keeping only its
+// last four characters when it is long enough to stay unguessable.
+type redacted string
+
+var _ slog.LogValuer = redacted("") //redacted is compatible with slog.LogValuer interface
+
+// LogValue method returns the masked value. This method is a implementation
+// of slog.LogValuer interface.
+func (s redacted) LogValue() slog.Value {
+	if len(s) < 12 {
+		return slog.StringValue("****")
+	}
+	return slog.StringValue("****" + string(s[len(s)-4:]))
+}
+
+// logRequest records the outcome of one HTTP attempt of op.
+func (c *client) logRequest(ctx context.Context, op Operation, payload []byte, status int, latency time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("operation", op.String()),
+		slog.String("marketplace", c.Marketplace()),
+		slog.Int("payload_bytes", len(payload)),
+		slog.Int("status", status),
+		slog.Duration("latency", latency),
+	}
+	if err == nil {
+		c.logger.LogAttrs(ctx, slog.LevelInfo, "pa-api request", attrs...)
+		return
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if len(apiErr.Code) > 0 {
+			attrs = append(attrs, slog.String("code", apiErr.Code))
+		}
+		if len(apiErr.RequestID) > 0 {
+			attrs = append(attrs, slog.String("request_id", apiErr.RequestID))
+		}
+	}
+	attrs = append(attrs, slog.String("error", err.Error()))
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "pa-api request failed", attrs...)
+}
+
+// logBody records b at Debug level.
+func (c *client) logBody(ctx context.Context, msg string, op Operation, b []byte) {
+	if c.logger == nil || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	c.logger.LogAttrs(ctx, slog.LevelDebug, msg,
+		slog.String("operation", op.String()),
+		slog.String("body", truncateForLog(b, maxDebugBodyBytes)),
+	)
+}
+
+// debugBody logs a 2xx response body at Debug level. The body is read into
+// memory only when Debug records are enabled; the returned reader replays
+// it.
+func (c *client) debugBody(ctx context.Context, op Operation, body io.ReadCloser) (io.ReadCloser, error) {
+	if c.logger == nil || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return body, nil
+	}
+	defer func() { _ = body.Close() }()
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	c.logBody(ctx, "pa-api response body", op, b)
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+// logRetry records a wait before the next attempt of op.
+func (c *client) logRetry(ctx context.Context, op Operation, attempt int, wait time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+	c.logger.LogAttrs(ctx, slog.LevelInfo, "pa-api retry",
+		slog.String("operation", op.String()),
+		slog.Int("attempt", attempt),
+		slog.Duration("wait", wait),
+		slog.String("error", err.Error()),
+	)
+}
+
+// log records a token manager event with the endpoint and masked
+// credential ID.
+func (t *tokenManager) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	if t.logger == nil {
+		return
+	}
+	attrs = append([]slog.Attr{
+		slog.String("endpoint", t.endpoint),
+		slog.Any("credential_id", redacted(t.clientID)),
+	}, attrs...)
+	t.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,150 @@
+package paapi5
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// logRecorder is an io.Writer collecting JSON log records.
+type logRecorder struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (r *logRecorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.buf.Write(p)
+}
+
+func (r *logRecorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.buf.String()
+}
+
+// records returns the records with the given message.
+func (r *logRecorder) records(t *testing.T, msg string) []map[string]any {
+	t.Helper()
+	list := []map[string]any{}
+	for line := range strings.Lines(r.String()) {
+		rec := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("invalid log record %q: %v", line, err)
+		}
+		if rec["msg"] == msg {
+			list = append(list, rec)
+		}
+	}
+	return list
+}
+
+func TestClientWithLogger(t *testing.T) {
+	const (
+		accessToken = "very-secret-access-token"
+		credID      = "credential-id-1234"
+		credSecret  = "credential-secret-5678"
+	)
+	tokenHandler := func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": accessToken, "expires_in": 3600})
+	}
+	calls := 0
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("x-amzn-RequestId", "req-1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"errors":[{"code":"TooManyRequests","message":"slow down"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"itemsResult":{}}`))
+	}
+	_, _, sv := newServers(t, tokenHandler, apiHandler)
+
+	for _, tc := range []struct {
+		level     slog.Level
+		wantDebug bool
+	}{
+		{level: slog.LevelInfo, wantDebug: false},
+		{level: slog.LevelDebug, wantDebug: true},
+	} {
+		calls = 0
+		rec := &logRecorder{}
+		logger := slog.New(slog.NewJSONHandler(rec, &slog.HandlerOptions{Level: tc.level}))
+		c := sv.CreateClient("tag", credID, credSecret, WithLogger(logger), WithRetryPolicy(fastRetryPolicy(2)))
+		b, err := c.RequestContext(context.Background(), stubQuery{op: GetItems, payload: []byte(`{"itemIds":["B0"]}`)})
+		if err != nil || string(b) != `{"itemsResult":{}}` {
+			t.Fatalf("RequestContext = %s, %+v", b, err)
+		}
+
+		out := rec.String()
+		for _, secret := range []string{accessToken, credID, credSecret, "Bearer"} {
+			if strings.Contains(out, secret) {
+				t.Errorf("log output contains %q:\n%s", secret, out)
+			}
+		}
+		if got := rec.records(t, "pa-api token refreshed"); len(got) != 1 || got[0]["credential_id"] != "****1234" {
+			t.Errorf("token refreshed records = %v", got)
+		}
+		failed := rec.records(t, "pa-api request failed")
+		if len(failed) != 1 || failed[0]["level"] != "WARN" || failed[0]["status"] != float64(429) || failed[0]["code"] != "TooManyRequests" || failed[0]["request_id"] != "req-1" {
+			t.Errorf("request failed records = %v", failed)
+		}
+		if got := rec.records(t, "pa-api retry"); len(got) != 1 || got[0]["attempt"] != float64(1) {
+			t.Errorf("retry records = %v", got)
+		}
+		ok := rec.records(t, "pa-api request")
+		if len(ok) != 1 || ok[0]["operation"] != "GetItems" || ok[0]["marketplace"] != "www.amazon.com" || ok[0]["status"] != float64(200) || ok[0]["payload_bytes"] != float64(18) {
+			t.Errorf("request records = %v", ok)
+		}
+		if _, found := ok[0]["latency"]; !found {
+			t.Errorf("request record has no latency: %v", ok[0])
+		}
+		payloads, bodies := rec.records(t, "pa-api request payload"), rec.records(t, "pa-api response body")
+		if !tc.wantDebug {
+			if len(payloads)+len(bodies) != 0 {
+				t.Errorf("bodies logged at %v: %v %v", tc.level, payloads, bodies)
+			}
+			continue
+		}
+		if len(payloads) != 2 || payloads[0]["body"] != `{"itemIds":["B0"]}` {
+			t.Errorf("payload records = %v", payloads)
+		}
+		if len(bodies) != 2 || bodies[1]["body"] != `{"itemsResult":{}}` {
+			t.Errorf("response body records = %v", bodies)
+		}
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	for _, tc := range []struct{ in, want string }{
+		{in: "", want: "****"},
+		{in: "short-id", want: "****"},
+		{in: "0123456789abcdef", want: "****cdef"},
+	} {
+		if got := redacted(tc.in).LogValue().String(); got != tc.want {
+			t.Errorf("redacted(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
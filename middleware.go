@@ -0,0 +1,86 @@
+package paapi5
+
+import (
+	"context"
+	"io"
+)
+
+// Handler interface issues one Creators API request. The payload has
+// already been rendered from q; the handler returns the response body.
+type Handler interface {
+	Handle(ctx context.Context, op Operation, q Query, payload []byte) ([]byte, error)
+}
+
+// HandlerFunc type is an adapter to allow the use of ordinary functions
+// as Handler.
+type HandlerFunc func(ctx context.Context, op Operation, q Query, payload []byte) ([]byte, error)
+
+var _ Handler = HandlerFunc(nil) //HandlerFunc is compatible with Handler interface
+
+// Handle calls f(ctx, op, q, payload).
+func (f HandlerFunc) Handle(ctx context.Context, op Operation, q Query, payload []byte) ([]byte, error) {
+	return f(ctx, op, q, payload)
+}
+
+// Middleware type wraps a Handler with additional behaviour such as
+// logging, caching, auditing or fault injection. A middleware may answer
+// without calling next, and may pass a different payload to it.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware function returns a ClientOptFunc that wraps every request
+// of the client with mw. The first middleware is the outermost one;
+// repeated options append to the chain. The innermost handler applies the
+// rate limit, the access token and the RetryPolicy, so a middleware sees
+// each request once regardless of retries.
+//
+// Requests issued by RequestStream are buffered while middleware is
+// configured, because the chain hands whole bodies around.
+func WithMiddleware(mw ...Middleware) ClientOptFunc {
+	return func(c *client) {
+		if c == nil {
+			return
+		}
+		for _, m := range mw {
+			if m != nil {
+				c.middleware = append(c.middleware, m)
+			}
+		}
+	}
+}
+
+// buildChain returns the middleware of the client wrapped around
+// roundTrip. CreateClient builds the chain once, so state a middleware
+// sets up when wrapping next is shared by all requests.
+func (c *client) buildChain() Handler {
+	var h Handler = HandlerFunc(c.roundTrip)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		h = c.middleware[i](h)
+	}
+	return h
+}
+
+// roundTrip is the innermost Handler: it posts payload (with retries) and
+// reads the whole response body.
+func (c *client) roundTrip(ctx context.Context, op Operation, _ Query, payload []byte) ([]byte, error) {
+	body, err := c.postWithRetry(ctx, op, payload)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = body.Close() }()
+	return io.ReadAll(body)
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
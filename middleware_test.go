@@ -0,0 +1,111 @@
+package paapi5
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClientWithMiddleware(t *testing.T) {
+	calls := 0
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		b, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(append([]byte(`{"echo":`), append(b, '}')...))
+	}
+	_, _, sv := newServers(t, okTokenHandler, apiHandler)
+
+	trace := []string{}
+	wraps := 0
+	tag := func(name string) Middleware {
+		return func(next Handler) Handler {
+			wraps++
+			return HandlerFunc(func(ctx context.Context, op Operation, q Query, payload []byte) ([]byte, error) {
+				trace = append(trace, name+">"+op.String())
+				b, err := next.Handle(ctx, op, q, payload)
+				trace = append(trace, name+"<")
+				return b, err
+			})
+		}
+	}
+	rewrite := func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, op Operation, q Query, payload []byte) ([]byte, error) {
+			if _, ok := q.(stubQuery); !ok {
+				t.Errorf("query = %T, want stubQuery", q)
+			}
+			return next.Handle(ctx, op, q, []byte(`{"rewritten":true}`))
+		})
+	}
+	c := sv.CreateClient("tag", "id", "secret", WithMiddleware(tag("a"), nil, tag("b")), WithMiddleware(rewrite))
+
+	q := stubQuery{op: SearchItems, payload: []byte(`{}`)}
+	for range 2 {
+		b, err := c.RequestContext(context.Background(), q)
+		if err != nil {
+			t.Fatalf("RequestContext: %+v", err)
+		}
+		if got, want := string(b), `{"echo":{"rewritten":true}}`; got != want {
+			t.Errorf("body = %s, want %s", got, want)
+		}
+	}
+	if want := []string{"a>SearchItems", "b>SearchItems", "b<", "a<", "a>SearchItems", "b>SearchItems", "b<", "a<"}; !reflect.DeepEqual(trace, want) {
+		t.Errorf("trace = %v, want %v", trace, want)
+	}
+	if wraps != 2 {
+		t.Errorf("middleware wrapped %d times, want 2 (once per middleware)", wraps)
+	}
+
+	body, err := RequestStream(context.Background(), c, q)
+	if err != nil {
+		t.Fatalf("RequestStream: %+v", err)
+	}
+	defer body.Close()
+	if b, _ := io.ReadAll(body); string(b) != `{"echo":{"rewritten":true}}` {
+		t.Errorf("streamed body = %s", b)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
+
+func TestClientMiddlewareShortCircuit(t *testing.T) {
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		t.Error("API should not be called")
+	}
+	_, _, sv := newServers(t, okTokenHandler, apiHandler)
+	injected := errors.New("injected fault")
+	c := sv.CreateClient("tag", "id", "secret", WithMiddleware(func(next Handler) Handler {
+		return HandlerFunc(func(ctx context.Context, op Operation, q Query, payload []byte) ([]byte, error) {
+			if op == GetItems {
+				return []byte(`{"cached":true}`), nil
+			}
+			return nil, injected
+		})
+	}))
+
+	b, err := c.RequestContext(context.Background(), stubQuery{op: GetItems, payload: []byte(`{}`)})
+	if err != nil || string(b) != `{"cached":true}` {
+		t.Errorf("RequestContext = %s, %v", b, err)
+	}
+	if _, err := c.RequestContext(context.Background(), stubQuery{op: GetVariations, payload: []byte(`{}`)}); !errors.Is(err, injected) {
+		t.Errorf("RequestContext error = %v, want %v", err, injected)
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -1,6 +1,8 @@
 package paapi5
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"strconv"
 	"strings"
@@ -51,6 +53,21 @@ func (c Operation) Path() string {
 	return ""
 }
 
+// CacheKey function returns a SHA-256 hash in hexadecimal of op, the
+// marketplace (host name or locale, as passed to the client) and the
+// canonical request body. The marketplace travels in the `x-marketplace`
+// header rather than the body, so it is hashed separately to keep keys of
+// different marketplaces apart.
+func CacheKey(op Operation, marketplace string, payload []byte) string {
+	h := sha256.New()
+	h.Write([]byte(op.String()))
+	h.Write([]byte{0})
+	h.Write([]byte(marketplace))
+	h.Write([]byte{0})
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // Target returns the value historically used for the X-Amz-Target header
 // under PA-API v5 SigV4 signing.
 //
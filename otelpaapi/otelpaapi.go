@@ -0,0 +1,302 @@
+// Package otelpaapi instruments a Creators API client with OpenTelemetry
+// tracing and metrics.
+package otelpaapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/goark/errs"
+	paapi5 "github.com/goark/pa-api"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/goark/pa-api/otelpaapi"
+
+// TokenRefreshSpanName is the name of the child span covering an OAuth2
+// token refresh.
+const TokenRefreshSpanName = "TokenRefresh"
+
+// Attribute keys set on spans and metrics.
+const (
+	OperationKey   = attribute.Key("paapi.operation")
+	MarketplaceKey = attribute.Key("paapi.marketplace")
+	ItemCountKey   = attribute.Key("paapi.item_count")
+	ErrorCodeKey   = attribute.Key("paapi.error_code")
+	ErrorCodesKey  = attribute.Key("paapi.error_codes")
+	StatusCodeKey  = attribute.Key("http.response.status_code")
+)
+
+// config holds the settings of NewClient.
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// OptFunc type is self-referential function type for NewClient function. (functional options pattern)
+type OptFunc func(*config)
+
+// WithTracerProvider function returns an OptFunc that sets the
+// TracerProvider. The global provider is used by default.
+func WithTracerProvider(tp trace.TracerProvider) OptFunc {
+	return func(cfg *config) {
+		if cfg != nil && tp != nil {
+			cfg.tracerProvider = tp
+		}
+	}
+}
+
+// WithMeterProvider function returns an OptFunc that sets the
+// MeterProvider. The global provider is used by default.
+func WithMeterProvider(mp metric.MeterProvider) OptFunc {
+	return func(cfg *config) {
+		if cfg != nil && mp != nil {
+			cfg.meterProvider = mp
+		}
+	}
+}
+
+// client wraps a paapi5.Client with spans and metrics.
+type client struct {
+	paapi5.Client
+	tracer    trace.Tracer
+	requests  metric.Int64Counter
+	throttles metric.Int64Counter
+	duration  metric.Float64Histogram
+}
+
+var _ paapi5.StreamClient = (*client)(nil) //client is compatible with paapi5.StreamClient interface
+var _ io.Closer = (*client)(nil)           //client is compatible with io.Closer interface
+
+// NewClient function returns a Client that wraps c. Every request runs in
+// a client span named after its Operation, with the marketplace, the
+// number of items returned and the error codes as attributes; OAuth2
+// token refreshes of the built-in token manager become child spans, and
+// retries and rate limiter waits become span events.
+//
+// Each HTTP attempt is counted by the paapi.client.requests counter and
+// timed by the paapi.client.request.duration histogram; throttled (HTTP
+// 429) attempts are also counted by paapi.client.throttles.
+func NewClient(c paapi5.Client, opts ...OptFunc) (paapi5.Client, error) {
+	if c == nil {
+		return nil, errs.Wrap(paapi5.ErrNullPointer, errs.WithContext("reason", "nil client"))
+	}
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.tracerProvider == nil {
+		cfg.tracerProvider = otel.GetTracerProvider()
+	}
+	if cfg.meterProvider == nil {
+		cfg.meterProvider = otel.GetMeterProvider()
+	}
+	meter := cfg.meterProvider.Meter(ScopeName)
+	oc := &client{Client: c, tracer: cfg.tracerProvider.Tracer(ScopeName)}
+	var err error
+	if oc.requests, err = meter.Int64Counter("paapi.client.requests",
+		metric.WithDescription("Number of HTTP attempts to the Creators API."),
+		metric.WithUnit("{request}"),
+	); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if oc.throttles, err = meter.Int64Counter("paapi.client.throttles",
+		metric.WithDescription("Number of HTTP attempts throttled by the Creators API."),
+		metric.WithUnit("{request}"),
+	); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if oc.duration, err = meter.Float64Histogram("paapi.client.request.duration",
+		metric.WithDescription("Latency of HTTP attempts to the Creators API."),
+		metric.WithUnit("s"),
+	); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return oc, nil
+}
+
+// Request issues q with a background context.
+func (c *client) Request(q paapi5.Query) ([]byte, error) {
+	return c.RequestContext(context.Background(), q)
+}
+
+// RequestContext issues q in a span named after its Operation.
+func (c *client) RequestContext(ctx context.Context, q paapi5.Query) ([]byte, error) {
+	if q == nil {
+		return c.Client.RequestContext(ctx, q)
+	}
+	ctx, span := c.start(ctx, q.Operation())
+	defer span.End()
+	b, err := c.Client.RequestContext(ctx, q)
+	if err != nil {
+		c.fail(span, err)
+		return nil, err
+	}
+	var sum summary
+	if derr := json.Unmarshal(b, &sum); derr == nil {
+		span.SetAttributes(ItemCountKey.Int(sum.itemCount()))
+		if list := sum.errorCodes(); len(list) > 0 {
+			span.SetAttributes(ErrorCodesKey.StringSlice(list))
+		}
+	}
+	return b, nil
+}
+
+// RequestStream issues q in a span named after its Operation. The span
+// ends when the response headers have arrived; the item count is not
+// recorded.
+func (c *client) RequestStream(ctx context.Context, q paapi5.Query) (io.ReadCloser, error) {
+	if q == nil {
+		return paapi5.RequestStream(ctx, c.Client, q)
+	}
+	ctx, span := c.start(ctx, q.Operation())
+	defer span.End()
+	body, err := paapi5.RequestStream(ctx, c.Client, q)
+	if err != nil {
+		c.fail(span, err)
+		return nil, err
+	}
+	return body, nil
+}
+
+// RemainingDailyQuota returns the remaining daily quota of the wrapped
+// client (see paapi5.RemainingDailyQuota).
+func (c *client) RemainingDailyQuota() (int, bool) {
+	return paapi5.RemainingDailyQuota(c.Client)
+}
+
+// Close closes the wrapped client if it implements io.Closer. This method
+// is a implementation of io.Closer interface.
+func (c *client) Close() error {
+	if closer, ok := c.Client.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// start starts the span of a request of op and attaches the ClientTrace
+// feeding it to ctx.
+func (c *client) start(ctx context.Context, op paapi5.Operation) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{OperationKey.String(op.String()), MarketplaceKey.String(c.Marketplace())}
+	ctx, span := c.tracer.Start(ctx, op.String(), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	return paapi5.WithClientTrace(ctx, c.clientTrace(attrs)), span
+}
+
+// fail records err on span.
+func (c *client) fail(span trace.Span, err error) {
+	var apiErr *paapi5.APIError
+	if errors.As(err, &apiErr) {
+		span.SetAttributes(StatusCodeKey.Int(apiErr.StatusCode))
+		if len(apiErr.Code) > 0 {
+			span.SetAttributes(ErrorCodeKey.String(apiErr.Code))
+		}
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// clientTrace returns the hooks that feed the span of the request and the
+// metrics.
+func (c *client) clientTrace(attrs []attribute.KeyValue) *paapi5.ClientTrace {
+	return &paapi5.ClientTrace{
+		RateLimitWait: func(ctx context.Context, op paapi5.Operation, wait time.Duration) {
+			if wait > 0 {
+				trace.SpanFromContext(ctx).AddEvent("rate_limit_wait", trace.WithAttributes(attribute.String("wait", wait.String())))
+			}
+		},
+		AttemptDone: func(ctx context.Context, info paapi5.AttemptInfo) {
+			set := metric.WithAttributeSet(attribute.NewSet(append(attrs, StatusCodeKey.Int(info.StatusCode))...))
+			c.requests.Add(ctx, 1, set)
+			c.duration.Record(ctx, info.Latency.Seconds(), set)
+			if info.StatusCode == http.StatusTooManyRequests {
+				c.throttles.Add(ctx, 1, set)
+			}
+		},
+		Retry: func(ctx context.Context, op paapi5.Operation, attempt int, wait time.Duration, err error) {
+			trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+				attribute.Int("attempt", attempt),
+				attribute.String("wait", wait.String()),
+				attribute.String("error", err.Error()),
+			))
+		},
+		TokenRefresh: func(ctx context.Context) (context.Context, func(error)) {
+			ctx, span := c.tracer.Start(ctx, TokenRefreshSpanName, trace.WithSpanKind(trace.SpanKindClient))
+			return ctx, func(err error) {
+				if err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+				}
+				span.End()
+			}
+		},
+	}
+}
+
+// summary is the part of a response recorded on spans. Items and browse
+// nodes decode to empty structs, so only their count is kept and their
+// members are skipped rather than decoded.
+type summary struct {
+	ItemsResult *struct {
+		Items []struct{} `json:"items"`
+	} `json:"itemsResult"`
+	SearchResult *struct {
+		Items []struct{} `json:"items"`
+	} `json:"searchResult"`
+	VariationsResult *struct {
+		Items []struct{} `json:"items"`
+	} `json:"variationsResult"`
+	BrowseNodesResult *struct {
+		BrowseNodes []struct{} `json:"browseNodes"`
+	} `json:"browseNodesResult"`
+	Errors []struct {
+		Code string `json:"code"`
+	} `json:"errors"`
+}
+
+// itemCount returns the number of items (or browse nodes) in the response.
+func (s *summary) itemCount() int {
+	switch {
+	case s.ItemsResult != nil:
+		return len(s.ItemsResult.Items)
+	case s.SearchResult != nil:
+		return len(s.SearchResult.Items)
+	case s.VariationsResult != nil:
+		return len(s.VariationsResult.Items)
+	case s.BrowseNodesResult != nil:
+		return len(s.BrowseNodesResult.BrowseNodes)
+	}
+	return 0
+}
+
+// errorCodes returns the codes of the per-item errors in the response.
+func (s *summary) errorCodes() []string {
+	list := make([]string, 0, len(s.Errors))
+	for _, e := range s.Errors {
+		list = append(list, e.Code)
+	}
+	return list
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
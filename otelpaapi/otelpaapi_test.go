@@ -0,0 +1,171 @@
+package otelpaapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	paapi5 "github.com/goark/pa-api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type stubQuery struct {
+	op paapi5.Operation
+}
+
+func (s stubQuery) Operation() paapi5.Operation { return s.op }
+func (s stubQuery) Payload() ([]byte, error)    { return []byte(`{}`), nil }
+
+// newClient returns a client talking to an API server that answers with
+// the given statuses in turn (200 afterwards), instrumented with in-memory
+// exporters.
+func newClient(t *testing.T, statuses ...int) (paapi5.Client, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	t.Helper()
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "tok", "expires_in": 3600})
+	}))
+	t.Cleanup(tokenSrv.Close)
+	calls := 0
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= len(statuses) {
+			w.WriteHeader(statuses[calls-1])
+			_, _ = w.Write([]byte(`{"errors":[{"code":"TooManyRequests","message":"slow down"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"errors":[{"code":"ItemNotAccessible","message":"The ItemId B0000000XX is not accessible through the Creators API."}],"itemsResult":{"items":[{"asin":"B000000001"},{"asin":"B000000002"}]}}`))
+	}))
+	t.Cleanup(apiSrv.Close)
+	sv := paapi5.New(
+		paapi5.WithMarketplace(paapi5.LocaleJapan),
+		paapi5.WithServerScheme("http"),
+		paapi5.WithServerHost(strings.TrimPrefix(apiSrv.URL, "http://")),
+		paapi5.WithServerAuthEndpoint(tokenSrv.URL),
+	)
+	retry := paapi5.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	exp := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	c, err := NewClient(sv.CreateClient("tag", "id", "secret", paapi5.WithRetryPolicy(retry)),
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c, exp, reader
+}
+
+func attrsOf(kvs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := map[attribute.Key]attribute.Value{}
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+// sums returns the values of an Int64 sum metric keyed by status code.
+func sums(t *testing.T, reader *sdkmetric.ManualReader, name string) map[int64]int64 {
+	t.Helper()
+	rm := metricdata.ResourceMetrics{}
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	got := map[int64]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				status, _ := dp.Attributes.Value(StatusCodeKey)
+				got[status.AsInt64()] += dp.Value
+			}
+		}
+	}
+	return got
+}
+
+func TestClientSpansAndMetrics(t *testing.T) {
+	c, exp, reader := newClient(t, http.StatusTooManyRequests)
+	if _, err := c.RequestContext(context.Background(), stubQuery{op: paapi5.GetItems}); err != nil {
+		t.Fatalf("RequestContext: %+v", err)
+	}
+
+	spans := exp.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("spans = %d, want 2", len(spans))
+	}
+	refresh, req := spans[0], spans[1]
+	if refresh.Name != TokenRefreshSpanName || refresh.Parent.SpanID() != req.SpanContext.SpanID() {
+		t.Errorf("token refresh span = %q (parent %v), want a child of %v", refresh.Name, refresh.Parent.SpanID(), req.SpanContext.SpanID())
+	}
+	if req.Name != "GetItems" || req.Status.Code == codes.Error {
+		t.Errorf("request span = %q (%v)", req.Name, req.Status)
+	}
+	attrs := attrsOf(req.Attributes)
+	if got := attrs[MarketplaceKey].AsString(); got != "www.amazon.co.jp" {
+		t.Errorf("%s = %q", MarketplaceKey, got)
+	}
+	if got := attrs[ItemCountKey].AsInt64(); got != 2 {
+		t.Errorf("%s = %d, want 2", ItemCountKey, got)
+	}
+	if got := attrs[ErrorCodesKey].AsStringSlice(); len(got) != 1 || got[0] != "ItemNotAccessible" {
+		t.Errorf("%s = %v", ErrorCodesKey, got)
+	}
+	if len(req.Events) != 1 || req.Events[0].Name != "retry" {
+		t.Errorf("events = %v, want one retry", req.Events)
+	}
+
+	if got := sums(t, reader, "paapi.client.requests"); got[429] != 1 || got[200] != 1 {
+		t.Errorf("requests = %v", got)
+	}
+	if got := sums(t, reader, "paapi.client.throttles"); got[429] != 1 || len(got) != 1 {
+		t.Errorf("throttles = %v", got)
+	}
+}
+
+func TestClientSpanError(t *testing.T) {
+	c, exp, _ := newClient(t, http.StatusTooManyRequests, http.StatusTooManyRequests)
+	body, err := paapi5.RequestStream(context.Background(), c, stubQuery{op: paapi5.SearchItems})
+	if !errors.Is(err, paapi5.ErrTooManyRequests) {
+		t.Fatalf("RequestStream error = %v, want %v", err, paapi5.ErrTooManyRequests)
+	}
+	if body != nil {
+		t.Error("RequestStream returned a body on failure")
+	}
+	spans := exp.GetSpans()
+	req := spans[len(spans)-1]
+	attrs := attrsOf(req.Attributes)
+	if req.Name != "SearchItems" || req.Status.Code != codes.Error || attrs[ErrorCodeKey].AsString() != "TooManyRequests" || attrs[StatusCodeKey].AsInt64() != 429 {
+		t.Errorf("span = %q %v %v", req.Name, req.Status, req.Attributes)
+	}
+	if _, err := NewClient(nil); !errors.Is(err, paapi5.ErrNullPointer) {
+		t.Errorf("NewClient(nil) error = %v, want %v", err, paapi5.ErrNullPointer)
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,152 @@
+// Package prompaapi records the activity of Creators API clients as
+// Prometheus metrics.
+package prompaapi
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	paapi5 "github.com/goark/pa-api"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// namespace prefixes the names of all metrics.
+const namespace = "paapi"
+
+// Collector type is a prometheus.Collector fed by the ClientTrace hooks of
+// one or more clients:
+//
+//   - paapi_client_requests_total{operation, marketplace, status}: HTTP
+//     attempts by status code ("0" if no reply arrived);
+//   - paapi_client_request_duration_seconds{operation, marketplace}: latency
+//     of HTTP attempts;
+//   - paapi_client_retries_total{operation}: retries;
+//   - paapi_client_rate_limit_wait_seconds{operation}: time spent waiting
+//     for the rate limiter (see paapi5.WithRateLimit);
+//   - paapi_client_token_refreshes_total and
+//     paapi_client_token_refresh_failures_total: OAuth2 token refreshes of
+//     the built-in token manager.
+type Collector struct {
+	requests        *prometheus.CounterVec
+	duration        *prometheus.HistogramVec
+	retries         *prometheus.CounterVec
+	rateLimitWait   *prometheus.HistogramVec
+	tokenRefreshes  prometheus.Counter
+	tokenRefreshErr prometheus.Counter
+}
+
+var _ prometheus.Collector = (*Collector)(nil) //Collector is compatible with prometheus.Collector interface
+
+// NewCollector function returns a new Collector. Register it with a
+// prometheus.Registerer and pass ClientTrace to the clients to observe:
+//
+//	col := prompaapi.NewCollector()
+//	prometheus.MustRegister(col)
+//	client := paapi5.New().CreateClient(tag, id, secret, paapi5.WithTrace(col.ClientTrace()))
+func NewCollector() *Collector {
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "client",
+			Name:      "requests_total",
+			Help:      "Number of HTTP attempts to the Creators API by status code.",
+		}, []string{"operation", "marketplace", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "client",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP attempts to the Creators API.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "marketplace"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "client",
+			Name:      "retries_total",
+			Help:      "Number of retried Creators API requests.",
+		}, []string{"operation"}),
+		rateLimitWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "client",
+			Name:      "rate_limit_wait_seconds",
+			Help:      "Time spent waiting for the client-side rate limiter.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		tokenRefreshes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "client",
+			Name:      "token_refreshes_total",
+			Help:      "Number of OAuth2 access token refreshes.",
+		}),
+		tokenRefreshErr: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "client",
+			Name:      "token_refresh_failures_total",
+			Help:      "Number of failed OAuth2 access token refreshes.",
+		}),
+	}
+}
+
+// collectors returns the metrics of c.
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{c.requests, c.duration, c.retries, c.rateLimitWait, c.tokenRefreshes, c.tokenRefreshErr}
+}
+
+// Describe method sends the descriptors of the metrics to ch. This method
+// is a implementation of prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range c.collectors() {
+		m.Describe(ch)
+	}
+}
+
+// Collect method sends the current values of the metrics to ch. This
+// method is a implementation of prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c.collectors() {
+		m.Collect(ch)
+	}
+}
+
+// ClientTrace method returns the hooks feeding c. Pass them to a client
+// with paapi5.WithTrace, which also covers background token refreshes, or
+// attach them to a request context with paapi5.WithClientTrace.
+func (c *Collector) ClientTrace() *paapi5.ClientTrace {
+	return &paapi5.ClientTrace{
+		RateLimitWait: func(_ context.Context, op paapi5.Operation, wait time.Duration) {
+			c.rateLimitWait.WithLabelValues(op.String()).Observe(wait.Seconds())
+		},
+		AttemptDone: func(_ context.Context, info paapi5.AttemptInfo) {
+			op := info.Operation.String()
+			c.requests.WithLabelValues(op, info.Marketplace, strconv.Itoa(info.StatusCode)).Inc()
+			c.duration.WithLabelValues(op, info.Marketplace).Observe(info.Latency.Seconds())
+		},
+		Retry: func(_ context.Context, op paapi5.Operation, _ int, _ time.Duration, _ error) {
+			c.retries.WithLabelValues(op.String()).Inc()
+		},
+		TokenRefresh: func(ctx context.Context) (context.Context, func(error)) {
+			return ctx, func(err error) {
+				if err != nil {
+					c.tokenRefreshErr.Inc()
+					return
+				}
+				c.tokenRefreshes.Inc()
+			}
+		},
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,122 @@
+package prompaapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	paapi5 "github.com/goark/pa-api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type stubQuery struct {
+	op paapi5.Operation
+}
+
+func (s stubQuery) Operation() paapi5.Operation { return s.op }
+func (s stubQuery) Payload() ([]byte, error)    { return []byte(`{}`), nil }
+
+// newServer returns a Server whose token endpoint answers with tokenStatus
+// and whose API answers 429 once, then 200.
+func newServer(t *testing.T, tokenStatus int) *paapi5.Server {
+	t.Helper()
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tokenStatus != http.StatusOK {
+			w.WriteHeader(tokenStatus)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "tok", "expires_in": 3600})
+	}))
+	t.Cleanup(tokenSrv.Close)
+	calls := 0
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(apiSrv.Close)
+	return paapi5.New(
+		paapi5.WithServerScheme("http"),
+		paapi5.WithServerHost(strings.TrimPrefix(apiSrv.URL, "http://")),
+		paapi5.WithServerAuthEndpoint(tokenSrv.URL),
+	)
+}
+
+func TestCollector(t *testing.T) {
+	col := NewCollector()
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(col); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	retry := paapi5.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	c := newServer(t, http.StatusOK).CreateClient("tag", "id", "secret",
+		paapi5.WithTrace(col.ClientTrace()),
+		paapi5.WithRetryPolicy(retry),
+		paapi5.WithRateLimit(1000, 10, 0),
+	)
+	if _, err := c.RequestContext(context.Background(), stubQuery{op: paapi5.GetItems}); err != nil {
+		t.Fatalf("RequestContext: %+v", err)
+	}
+
+	for _, tc := range []struct {
+		c    prometheus.Collector
+		want float64
+	}{
+		{c: col.requests.WithLabelValues("GetItems", "www.amazon.com", "429"), want: 1},
+		{c: col.requests.WithLabelValues("GetItems", "www.amazon.com", "200"), want: 1},
+		{c: col.retries.WithLabelValues("GetItems"), want: 1},
+		{c: col.tokenRefreshes, want: 1},
+		{c: col.tokenRefreshErr, want: 0},
+	} {
+		if got := testutil.ToFloat64(tc.c); got != tc.want {
+			t.Errorf("metric = %v, want %v", got, tc.want)
+		}
+	}
+	if n, err := testutil.GatherAndCount(reg, "paapi_client_request_duration_seconds", "paapi_client_rate_limit_wait_seconds"); err != nil || n != 2 {
+		t.Errorf("histograms = %d (%v), want 2", n, err)
+	}
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP paapi_client_retries_total Number of retried Creators API requests.
+# TYPE paapi_client_retries_total counter
+paapi_client_retries_total{operation="GetItems"} 1
+`), "paapi_client_retries_total"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCollectorTokenFailure(t *testing.T) {
+	col := NewCollector()
+	c := newServer(t, http.StatusInternalServerError).CreateClient("tag", "id", "secret", paapi5.WithTrace(col.ClientTrace()))
+	if _, err := c.RequestContext(context.Background(), stubQuery{op: paapi5.GetItems}); err == nil {
+		t.Fatal("RequestContext should fail without a token")
+	}
+	if got := testutil.ToFloat64(col.tokenRefreshErr); got != 1 {
+		t.Errorf("token refresh failures = %v, want 1", got)
+	}
+	if got := testutil.CollectAndCount(col, "paapi_client_requests_total"); got != 0 {
+		t.Errorf("requests = %d, want 0", got)
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
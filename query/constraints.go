@@ -0,0 +1,134 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goark/errs"
+	paapi5 "github.com/goark/pa-api"
+)
+
+const (
+	maxItemIds       = 10 // maximum number of itemIds in GetItems
+	maxBrowseNodeIds = 10 // maximum number of browseNodeIds in GetBrowseNodes
+)
+
+// ConstraintError is a violation of a constraint of the Creators API
+// operation, found before the request is sent
+type ConstraintError struct {
+	Operation paapi5.Operation
+	Filters   []RequestFilter // filters concerned
+	Reason    string
+}
+
+// Error method returns error message.
+// This method is a implementation of error interface.
+func (e *ConstraintError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	names := make([]string, 0, len(e.Filters))
+	for _, f := range e.Filters {
+		names = append(names, f.String())
+	}
+	return fmt.Sprintf("%v: %s: %s", e.Operation, strings.Join(names, ", "), e.Reason)
+}
+
+// Unwrap method returns paapi5.ErrInvalidQuery
+func (e *ConstraintError) Unwrap() error {
+	return paapi5.ErrInvalidQuery
+}
+
+// numericConstraint is the allowed range of a numeric request parameter
+type numericConstraint struct {
+	filter   RequestFilter
+	min, max int
+	value    func(*request) int
+}
+
+var (
+	itemCountConstraint        = numericConstraint{ItemCount, 1, 10, func(r *request) int { return r.ItemCount }}
+	itemPageConstraint         = numericConstraint{ItemPage, 1, 10, func(r *request) int { return r.ItemPage }}
+	minReviewsRatingConstraint = numericConstraint{MinReviewsRating, 1, 4, func(r *request) int { return r.MinReviewsRating }}
+	minSavingPercentConstraint = numericConstraint{MinSavingPercent, 1, 99, func(r *request) int { return r.MinSavingPercent }}
+	minPriceConstraint         = numericConstraint{MinPrice, 1, 0, func(r *request) int { return r.MinPrice }}
+	maxPriceConstraint         = numericConstraint{MaxPrice, 1, 0, func(r *request) int { return r.MaxPrice }}
+	variationCountConstraint   = numericConstraint{VariationCount, 1, 10, func(r *request) int { return r.VariationCount }}
+	variationPageConstraint    = numericConstraint{VariationPage, 1, 0, func(r *request) int { return r.VariationPage }}
+
+	numericConstraintsMap = map[paapi5.Operation][]numericConstraint{
+		paapi5.SearchItems:   {itemCountConstraint, itemPageConstraint, minReviewsRatingConstraint, minSavingPercentConstraint, minPriceConstraint, maxPriceConstraint},
+		paapi5.GetVariations: {variationCountConstraint, variationPageConstraint},
+	}
+	// SearchItems requires at least one of these parameters
+	searchKeys = []RequestFilter{Actor, Artist, Author, Brand, BrowseNodeID, Keywords, Title}
+)
+
+// CheckConstraints checks the request against the constraints of the
+// Creators API operation: the ranges of itemCount and itemPage (1-10),
+// minReviewsRating (1-4) and minSavingPercent (1-99), at most ten itemIds
+// or browseNodeIds, and a search parameter for SearchItems. It returns the
+// violations joined into one error, or nil. Parameters left unset (zero)
+// are not checked. A numeric parameter whose value was rejected by Request
+// (see Validate) is a violation too, whether or not the query is strict:
+// the request would otherwise be sent without it.
+func (q *Query) CheckConstraints() error {
+	if q == nil {
+		return errs.Wrap(paapi5.ErrNullPointer)
+	}
+	op := q.Operation()
+	errList := []error{}
+	for _, c := range numericConstraintsMap[op] {
+		if err := c.check(op, &q.request); err != nil {
+			errList = append(errList, err)
+		}
+		for _, e := range q.rejected {
+			if e.Filter == c.filter {
+				errList = append(errList, &ConstraintError{Operation: op, Filters: []RequestFilter{c.filter}, Reason: fmt.Sprintf("%#v %s", e.Value, e.Reason)})
+			}
+		}
+	}
+	switch op {
+	case paapi5.GetItems:
+		if n := len(q.ItemIds); n > maxItemIds {
+			errList = append(errList, &ConstraintError{Operation: op, Filters: []RequestFilter{ItemIds}, Reason: fmt.Sprintf("%d item IDs, at most %d allowed", n, maxItemIds)})
+		}
+	case paapi5.GetBrowseNodes:
+		if n := len(q.BrowseNodeIds); n > maxBrowseNodeIds {
+			errList = append(errList, &ConstraintError{Operation: op, Filters: []RequestFilter{BrowseNodeIds}, Reason: fmt.Sprintf("%d browse node IDs, at most %d allowed", n, maxBrowseNodeIds)})
+		}
+	case paapi5.SearchItems:
+		r := &q.request
+		if len(r.Actor)+len(r.Artist)+len(r.Author)+len(r.Brand)+len(r.BrowseNodeID)+len(r.Keywords)+len(r.Title) == 0 {
+			errList = append(errList, &ConstraintError{Operation: op, Filters: searchKeys, Reason: "one of them is required"})
+		}
+	}
+	return errs.Join(errList...)
+}
+
+func (c numericConstraint) check(op paapi5.Operation, r *request) error {
+	v := c.value(r)
+	if v == 0 || (v >= c.min && (c.max == 0 || v <= c.max)) {
+		return nil
+	}
+	reason := fmt.Sprintf("%d must be between %d and %d", v, c.min, c.max)
+	if c.max == 0 {
+		reason = fmt.Sprintf("%d must be at least %d", v, c.min)
+	}
+	return &ConstraintError{Operation: op, Filters: []RequestFilter{c.filter}, Reason: reason}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,94 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	paapi5 "github.com/goark/pa-api"
+)
+
+func TestCheckConstraints(t *testing.T) {
+	ids := func(n int) []string {
+		list := []string{}
+		for i := range n {
+			list = append(list, fmt.Sprintf("49009000%02d", i))
+		}
+		return list
+	}
+	search := func() *SearchItems { return NewSearchItems("", "mytag-20", "").Search(Keywords, "golang") }
+
+	tooManyItems := NewGetItems("", "mytag-20", "").ASINs(ids(11))
+	tooManyNodes := NewGetBrowseNodes("", "mytag-20", "").BrowseNodeIds(ids(11))
+	badSearch := search()
+	badSearch.ItemCount = 11
+	badSearch.ItemPage = -1
+	badSearch.MinReviewsRating = 5
+	badSearch.MinSavingPercent = 100
+	badVariations := NewGetVariations("", "mytag-20", "").ASIN("B07YCM5K55")
+	badVariations.VariationCount = 20
+
+	testCases := []struct {
+		q   paapi5.Query
+		msg string
+	}{
+		{q: NewGetItems("", "mytag-20", "").ASINs(ids(10))},
+		{q: NewGetBrowseNodes("", "mytag-20", "").BrowseNodeIds(ids(10))},
+		{q: search().Request(ItemCount, 10).Request(ItemPage, 10).Request(MinReviewsRating, 4).Request(MinSavingPercent, 99)},
+		{q: NewSearchItems("", "mytag-20", "").Request(BrowseNodeID, "3040")},
+		{q: tooManyItems, msg: "GetItems: ItemIds: 11 item IDs, at most 10 allowed"},
+		{q: tooManyNodes, msg: "GetBrowseNodes: BrowseNodeIds: 11 browse node IDs, at most 10 allowed"},
+		{q: NewSearchItems("", "mytag-20", "").Request(SearchIndex, "Books"), msg: "SearchItems: Actor, Artist, Author, Brand, BrowseNodeID, Keywords, Title: one of them is required"},
+		{q: badSearch, msg: "SearchItems: ItemCount: 11 must be between 1 and 10\nSearchItems: ItemPage: -1 must be between 1 and 10\nSearchItems: MinReviewsRating: 5 must be between 1 and 4\nSearchItems: MinSavingPercent: 100 must be between 1 and 99"},
+		{q: badVariations, msg: "GetVariations: VariationCount: 20 must be between 1 and 10"},
+		{q: search().Request(ItemCount, 20).Request(MinReviewsRating, 5), msg: "SearchItems: ItemCount: 20 must be between 1 and 10\nSearchItems: MinReviewsRating: 5 must be between 1 and 4"},
+		{q: search().Request(ItemPage, "2").Request(MinPrice, 0), msg: "SearchItems: ItemPage: \"2\" unexpected type string, want int\nSearchItems: MinPrice: 0 must be at least 1"},
+		{q: search().Request(ItemCount, 20).Request(ItemCount, 5)},
+		{q: NewGetVariations("", "mytag-20", "").ASIN("B07YCM5K55").Request(VariationCount, 11), msg: "GetVariations: VariationCount: 11 must be between 1 and 10"},
+	}
+	for _, tc := range testCases {
+		err := tc.q.(interface{ CheckConstraints() error }).CheckConstraints()
+		_, perr := tc.q.Payload()
+		if len(tc.msg) == 0 {
+			if err != nil || perr != nil {
+				t.Errorf("CheckConstraints() = %v, Payload() error = %v, want nil", err, perr)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tc.msg {
+			t.Errorf("CheckConstraints() = %v, want %q", err, tc.msg)
+		}
+		var cerr *ConstraintError
+		if !errors.Is(perr, paapi5.ErrInvalidQuery) || !errors.As(perr, &cerr) || cerr.Operation != tc.q.Operation() {
+			t.Errorf("Payload() error = %v, want a ConstraintError of %v", perr, tc.q.Operation())
+		}
+	}
+	if err := (*Query)(nil).CheckConstraints(); !errors.Is(err, paapi5.ErrNullPointer) {
+		t.Errorf("CheckConstraints() of nil query = %v, want %v", err, paapi5.ErrNullPointer)
+	}
+}
+
+func TestStringIgnoresConstraints(t *testing.T) {
+	q := NewSearchItems("", "mytag-20", "")
+	if got, want := q.String(), `{"partnerTag":"mytag-20"}`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if _, err := q.Payload(); !errors.Is(err, paapi5.ErrInvalidQuery) {
+		t.Errorf("Payload() error = %v, want %v", err, paapi5.ErrInvalidQuery)
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -30,10 +30,18 @@ var requestsOfGetBrowseNodes = []RequestFilter{
 func (q *GetBrowseNodes) Request(request RequestFilter, value interface{}) *GetBrowseNodes {
 	if request.findIn(requestsOfGetBrowseNodes) {
 		q.With().RequestFilters(RequestMap{request: value})
+	} else {
+		q.With().unsupported(request, value, requestsOfGetBrowseNodes)
 	}
 	return q
 }
 
+// Strict sets the strict mode, in which Payload fails on rejected filters (see Query.Validate)
+func (q *GetBrowseNodes) Strict() *GetBrowseNodes {
+	q.With().Strict()
+	return q
+}
+
 //BrowseNodeIds sets ItemIds in GetItems instance
 func (q *GetBrowseNodes) BrowseNodeIds(itms []string) *GetBrowseNodes {
 	return q.Request(BrowseNodeIds, itms)
@@ -35,10 +35,18 @@ var requestsOfGetItems = []RequestFilter{
 func (q *GetItems) Request(request RequestFilter, value interface{}) *GetItems {
 	if request.findIn(requestsOfGetItems) {
 		q.With().RequestFilters(RequestMap{request: value})
+	} else {
+		q.With().unsupported(request, value, requestsOfGetItems)
 	}
 	return q
 }
 
+// Strict sets the strict mode, in which Payload fails on rejected filters (see Query.Validate)
+func (q *GetItems) Strict() *GetItems {
+	q.With().Strict()
+	return q
+}
+
 // ASINs sets ItemIds in GetItems instance
 func (q *GetItems) ASINs(itms []string) *GetItems {
 	return q.Request(ItemIds, itms).Request(ItemIdType, "ASIN")
@@ -36,10 +36,18 @@ var requestsOfGetVariations = []RequestFilter{
 func (q *GetVariations) Request(request RequestFilter, value interface{}) *GetVariations {
 	if request.findIn(requestsOfGetVariations) {
 		q.With().RequestFilters(RequestMap{request: value})
+	} else {
+		q.With().unsupported(request, value, requestsOfGetVariations)
 	}
 	return q
 }
 
+// Strict sets the strict mode, in which Payload fails on rejected filters (see Query.Validate)
+func (q *GetVariations) Strict() *GetVariations {
+	q.With().Strict()
+	return q
+}
+
 // ASIN sets ASIN in GetVariations instance
 func (q *GetVariations) ASIN(itm string) *GetVariations {
 	return q.Request(ASIN, itm)
@@ -2,6 +2,7 @@ package query
 
 import (
 	"encoding/json"
+	"sort"
 
 	"github.com/goark/errs"
 	paapi5 "github.com/goark/pa-api"
@@ -18,6 +19,8 @@ type Query struct {
 	request
 	Resources       []string `json:"resources,omitempty"`
 	enableResources map[resource]bool
+	rejected        []*FilterError
+	strict          bool
 }
 
 var _ paapi5.Query = (*Query)(nil) //Query is compatible with paapi5.Query interface
@@ -36,17 +39,41 @@ func (q *Query) Operation() paapi5.Operation {
 }
 
 // Payload defines the resources to be returned and renders the request
-// body that will be POSTed to the Creators API.
+// body that will be POSTed to the Creators API. The rendering is canonical:
+// resources are listed in a fixed order and properties are sorted by key,
+// so equal queries always render the same bytes.
+//
+// Payload fails if the request violates a constraint of the operation (see
+// CheckConstraints) and, in strict mode (see Strict), if the query has
+// rejected any filter.
 func (q *Query) Payload() ([]byte, error) {
 	if q == nil {
 		return nil, errs.Wrap(paapi5.ErrNullPointer)
 	}
-	q.Resources = []string{}
+	if q.strict {
+		if err := q.Validate(); err != nil {
+			return nil, errs.Wrap(err, errs.WithContext("Operation", q.Operation().String()))
+		}
+	}
+	if err := q.CheckConstraints(); err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("Operation", q.Operation().String()))
+	}
+	return q.render()
+}
+
+// render renders the request body without any check.
+func (q *Query) render() ([]byte, error) {
+	enabled := make([]resource, 0, len(q.enableResources))
 	for r, flag := range q.enableResources {
 		if flag {
-			q.Resources = append(q.Resources, r.Strings()...)
+			enabled = append(enabled, r)
 		}
 	}
+	sort.Slice(enabled, func(i, j int) bool { return enabled[i] < enabled[j] })
+	q.Resources = []string{}
+	for _, r := range enabled {
+		q.Resources = append(q.Resources, r.Strings()...)
+	}
 	if len(q.Resources) == 0 {
 		q.Resources = nil
 	}
@@ -57,9 +84,24 @@ func (q *Query) Payload() ([]byte, error) {
 	return b, nil
 }
 
-// Stringer interface
-func (q *Query) String() string {
+// CacheKey returns the cache key of the query sent to marketplace (see
+// paapi5.CacheKey). The marketplace is not part of the payload, so it must
+// be given here; equal queries for the same marketplace have equal keys.
+func (q *Query) CacheKey(marketplace string) (string, error) {
 	b, err := q.Payload()
+	if err != nil {
+		return "", err
+	}
+	return paapi5.CacheKey(q.Operation(), marketplace, b), nil
+}
+
+// Stringer interface. The request body is rendered even if Payload would
+// fail on it.
+func (q *Query) String() string {
+	if q == nil {
+		return ""
+	}
+	b, err := q.render()
 	if err != nil {
 		return ""
 	}
@@ -80,8 +122,13 @@ type RequestMap map[RequestFilter]interface{}
 // RequestFilters adds RequestFilter to Query instance
 func (q *Query) RequestFilters(requests ...RequestMap) *Query {
 	for _, request := range requests {
-		for name, value := range request {
-			q.mapFilter(name, value)
+		filters := make([]RequestFilter, 0, len(request))
+		for filter := range request {
+			filters = append(filters, filter)
+		}
+		sort.Slice(filters, func(i, j int) bool { return filters[i] < filters[j] })
+		for _, filter := range filters {
+			q.reject(filter, q.mapFilter(filter, request[filter])...)
 		}
 	}
 	return q
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -150,6 +151,59 @@ func TestResources(t *testing.T) {
 	}
 }
 
+func TestPayloadCanonical(t *testing.T) {
+	q1 := New(paapi5.GetItems).VariationSummary().BrowseNodes().CustomerReviews().ParentASIN().SearchRefinements().OffersV2().ItemInfo().Images().BrowseNodeInfo()
+	q1.RequestFilters(RequestMap{Properties: map[string]string{"b": "2", "a": "1", "c": "3"}, ItemIds: []string{"4900900028"}})
+	q2 := New(paapi5.GetItems).BrowseNodeInfo().Images().ItemInfo().OffersV2().SearchRefinements().ParentASIN().CustomerReviews().BrowseNodes().VariationSummary()
+	q2.RequestFilters(RequestMap{ItemIds: []string{"4900900028"}, Properties: map[string]string{"c": "3", "a": "1", "b": "2"}})
+
+	want, err := q1.Payload()
+	if err != nil {
+		t.Fatalf("Payload() error = %+v", err)
+	}
+	for range 20 {
+		for _, q := range []*Query{q1, q2} {
+			if got, err := q.Payload(); err != nil || string(got) != string(want) {
+				t.Fatalf("Payload() = %s, %v, want %s", got, err, want)
+			}
+		}
+	}
+	resources := []string{}
+	for _, r := range []resource{resourceBrowseNodeInfo, resourceImages, resourceItemInfo, resourceOffersV2, resourceSearchRefinements, resourceParentASIN, resourceCustomerReviews, resourceBrowseNodes, resourceVariationSummary} {
+		resources = append(resources, r.Strings()...)
+	}
+	b, _ := json.Marshal(resources)
+	if golden := `{"itemIds":["4900900028"],"properties":{"a":"1","b":"2","c":"3"},"resources":` + string(b) + `}`; string(want) != golden {
+		t.Errorf("Payload() = %s, want %s", want, golden)
+	}
+
+	k1, err := q1.CacheKey("www.amazon.co.jp")
+	if err != nil {
+		t.Fatalf("CacheKey() error = %+v", err)
+	}
+	if k2, _ := q2.CacheKey("www.amazon.co.jp"); k1 != k2 || len(k1) != 64 {
+		t.Errorf("CacheKey() = %q and %q, want equal SHA-256 hex keys", k1, k2)
+	}
+	q3 := New(paapi5.GetVariations).VariationSummary().BrowseNodes().CustomerReviews().ParentASIN().SearchRefinements().OffersV2().ItemInfo().Images().BrowseNodeInfo()
+	q3.RequestFilters(RequestMap{Properties: map[string]string{"a": "1", "b": "2", "c": "3"}, ItemIds: []string{"4900900028"}})
+	if k3, _ := q3.CacheKey("www.amazon.co.jp"); k3 == k1 {
+		t.Error("CacheKey() of different operations should differ")
+	}
+	q2.RequestFilters(RequestMap{ItemIds: []string{"4900900036"}})
+	if k2, _ := q2.CacheKey("www.amazon.co.jp"); k2 == k1 {
+		t.Error("CacheKey() of different filters should differ")
+	}
+	if k4, _ := q1.CacheKey("www.amazon.com"); k4 == k1 {
+		t.Error("CacheKey() of different marketplaces should differ")
+	}
+	if k1 != paapi5.CacheKey(paapi5.GetItems, "www.amazon.co.jp", want) {
+		t.Error("CacheKey() should equal paapi5.CacheKey() of the payload")
+	}
+	if _, err := (*Query)(nil).CacheKey("www.amazon.co.jp"); !errors.Is(err, paapi5.ErrNullPointer) {
+		t.Errorf("CacheKey() of nil query error = %v, want %v", err, paapi5.ErrNullPointer)
+	}
+}
+
 /* Copyright 2019 Spiegel and contributors
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
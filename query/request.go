@@ -1,6 +1,9 @@
 package query
 
-import "strconv"
+import (
+	"fmt"
+	"strconv"
+)
 
 // RequestFilter signals the types of filters to use
 type RequestFilter int
@@ -26,11 +29,11 @@ const (
 	LanguagesOfPreference
 	Marketplace // Deprecated: forwarded as the `x-marketplace` request header by the client; not transmitted in the body.
 	MaxPrice
-	Merchant // Deprecated: removed in the Creators API; values are silently ignored.
+	Merchant // Deprecated: removed in the Creators API; values are rejected (see Query.Validate).
 	MinPrice
 	MinReviewsRating
 	MinSavingPercent
-	OfferCount // Deprecated: removed in the Creators API; values are silently ignored.
+	OfferCount // Deprecated: removed in the Creators API; values are rejected (see Query.Validate).
 	PartnerTag
 	PartnerType // Deprecated: not transmitted by the Creators API.
 	Properties
@@ -41,6 +44,49 @@ const (
 	VariationPage
 )
 
+var requestFilterNames = map[RequestFilter]string{
+	Actor:                 "Actor",
+	Artist:                "Artist",
+	ASIN:                  "ASIN",
+	Author:                "Author",
+	Availability:          "Availability",
+	Brand:                 "Brand",
+	BrowseNodeID:          "BrowseNodeID",
+	Condition:             "Condition",
+	CurrencyOfPreference:  "CurrencyOfPreference",
+	DeliveryFlags:         "DeliveryFlags",
+	ItemIds:               "ItemIds",
+	ItemIdType:            "ItemIdType",
+	ItemCount:             "ItemCount",
+	ItemPage:              "ItemPage",
+	Keywords:              "Keywords",
+	BrowseNodeIds:         "BrowseNodeIds",
+	LanguagesOfPreference: "LanguagesOfPreference",
+	Marketplace:           "Marketplace",
+	MaxPrice:              "MaxPrice",
+	Merchant:              "Merchant",
+	MinPrice:              "MinPrice",
+	MinReviewsRating:      "MinReviewsRating",
+	MinSavingPercent:      "MinSavingPercent",
+	OfferCount:            "OfferCount",
+	PartnerTag:            "PartnerTag",
+	PartnerType:           "PartnerType",
+	Properties:            "Properties",
+	SearchIndex:           "SearchIndex",
+	SortBy:                "SortBy",
+	Title:                 "Title",
+	VariationCount:        "VariationCount",
+	VariationPage:         "VariationPage",
+}
+
+// String is Stringer method
+func (f RequestFilter) String() string {
+	if name, ok := requestFilterNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("RequestFilter(%d)", int(f))
+}
+
 func (f RequestFilter) findIn(list []RequestFilter) bool {
 	for _, elm := range list {
 		if f == elm {
@@ -120,166 +166,175 @@ type request struct {
 
 // mapFilter is a helper function for (*filters).WithFilters
 // This function does not check, if the filters to be used match the chosen searchParam/searchType (Actor, Artist etc.pp.)
-func (r *request) mapFilter(filter RequestFilter, filterValue interface{}) {
+// Values of the wrong type or failing validation are not set; they are
+// returned as FilterError instances instead.
+func (r *request) mapFilter(filter RequestFilter, filterValue interface{}) []*FilterError {
+	var errList []*FilterError
+	setString := func(field *string) {
+		if param, err := filter.stringValue(filterValue); err != nil {
+			errList = append(errList, err)
+		} else if len(param) > 0 {
+			*field = param
+		}
+	}
+	setStrings := func(field *[]string) {
+		params, set, errs := filter.stringsValue(filterValue)
+		errList = append(errList, errs...)
+		if set {
+			*field = params
+		}
+	}
+	setInt := func(field *int, min, max int) {
+		if n, err := filter.intValue(filterValue, min, max); err != nil {
+			errList = append(errList, err)
+		} else {
+			*field = n
+		}
+	}
 	switch filter {
 	case Actor:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.Actor = param
-		}
+		setString(&r.Actor)
 	case Artist:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.Artist = param
-		}
+		setString(&r.Artist)
 	case ASIN:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.ASIN = param
-		}
+		setString(&r.ASIN)
 	case Availability:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.Availability = param
-		}
+		setString(&r.Availability)
 	case Author:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.Author = param
-		}
+		setString(&r.Author)
 	case Brand:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.Brand = param
-		}
+		setString(&r.Brand)
 	case BrowseNodeID:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.BrowseNodeID = param
-		}
+		setString(&r.BrowseNodeID)
 	case Condition:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.Condition = param
-		}
+		setString(&r.Condition)
 	case CurrencyOfPreference:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.CurrencyOfPreference = param
-		}
+		setString(&r.CurrencyOfPreference)
 	case DeliveryFlags:
-		switch v := filterValue.(type) {
-		case []string:
-			r.DeliveryFlags = []string{}
-			for _, param := range v {
-				if filter.isVlidString(param) {
-					r.DeliveryFlags = append(r.DeliveryFlags, param)
-				}
-			}
-		case string:
-			if filter.isVlidString(v) {
-				r.DeliveryFlags = []string{v}
-			}
-		}
+		setStrings(&r.DeliveryFlags)
 	case ItemIds:
-		switch v := filterValue.(type) {
-		case []string:
-			r.ItemIds = []string{}
-			for _, param := range v {
-				if filter.isVlidString(param) {
-					r.ItemIds = append(r.ItemIds, param)
-				}
-			}
-		case string:
-			if filter.isVlidString(v) {
-				r.ItemIds = []string{v}
-			}
-		}
+		setStrings(&r.ItemIds)
 	case ItemIdType:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.ItemIdType = param
-		}
+		setString(&r.ItemIdType)
 	case ItemCount:
-		if count, ok := filterValue.(int); ok && 0 < count && count < 11 {
-			r.ItemCount = count
-		}
+		setInt(&r.ItemCount, 1, 10)
 	case ItemPage:
-		if page, ok := filterValue.(int); ok && 0 < page && page < 11 {
-			r.ItemPage = page
-		}
+		setInt(&r.ItemPage, 1, 10)
 	case Keywords:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.Keywords = param
-		}
+		setString(&r.Keywords)
 	case BrowseNodeIds:
-		switch v := filterValue.(type) {
-		case []string:
-			r.BrowseNodeIds = []string{}
-			for _, param := range v {
-				if filter.isVlidString(param) {
-					r.BrowseNodeIds = append(r.BrowseNodeIds, param)
-				}
-			}
-		case string:
-			if filter.isVlidString(v) {
-				r.BrowseNodeIds = []string{v}
-			}
-		}
+		setStrings(&r.BrowseNodeIds)
 	case LanguagesOfPreference:
-		switch v := filterValue.(type) {
-		case []string:
-			r.LanguagesOfPreference = []string{}
-			for _, param := range v {
-				if filter.isVlidString(param) {
-					r.LanguagesOfPreference = append(r.LanguagesOfPreference, param)
-				}
-			}
-		case string:
-			if filter.isVlidString(v) {
-				r.LanguagesOfPreference = []string{v}
-			}
-		}
-	case Marketplace, Merchant, OfferCount, PartnerType:
+		setStrings(&r.LanguagesOfPreference)
+	case Marketplace, PartnerType:
 		// Removed in the Creators API: Marketplace travels in the
-		// `x-marketplace` header, PartnerType is implicit, and Merchant /
-		// OfferCount are no longer accepted.
+		// `x-marketplace` header and PartnerType is implicit. The query
+		// constructors set both, so they are dropped without a rejection.
+	case Merchant, OfferCount:
+		errList = append(errList, &FilterError{Filter: filter, Value: filterValue, Reason: "not accepted by the Creators API"})
 	case MaxPrice:
-		if price, ok := filterValue.(int); ok && price > 0 {
-			r.MaxPrice = price
-		}
+		setInt(&r.MaxPrice, 1, 0)
 	case MinPrice:
-		if price, ok := filterValue.(int); ok && price > 0 {
-			r.MinPrice = price
-		}
+		setInt(&r.MinPrice, 1, 0)
 	case MinReviewsRating:
-		if minRating, ok := filterValue.(int); ok && 0 < minRating && minRating < 5 {
-			r.MinReviewsRating = minRating
-		}
+		setInt(&r.MinReviewsRating, 1, 4)
 	case MinSavingPercent:
-		if minSaving, ok := filterValue.(int); ok && 0 < minSaving && minSaving < 100 {
-			r.MinSavingPercent = minSaving
-		}
+		setInt(&r.MinSavingPercent, 1, 99)
 	case PartnerTag:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.PartnerTag = param
-		}
+		setString(&r.PartnerTag)
 	case Properties:
-		if params, ok := filterValue.(map[string]string); ok && len(params) > 0 {
+		if params, ok := filterValue.(map[string]string); !ok {
+			errList = append(errList, filter.typeError(filterValue, "map[string]string"))
+		} else if len(params) > 0 {
 			r.Properties = params
 		}
 	case SearchIndex:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.SearchIndex = param
-		}
+		setString(&r.SearchIndex)
 	case SortBy:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.SortBy = param
-		}
+		setString(&r.SortBy)
 	case Title:
-		if param, ok := filterValue.(string); ok && filter.isVlidString(param) {
-			r.Title = param
-		}
+		setString(&r.Title)
 	case VariationCount:
-		if count, ok := filterValue.(int); ok && 0 < count && count < 11 {
-			r.VariationCount = count
-		}
+		setInt(&r.VariationCount, 1, 10)
 	case VariationPage:
-		if count, ok := filterValue.(int); ok && 0 < count {
-			r.VariationPage = count
+		setInt(&r.VariationPage, 1, 0)
+	default:
+		errList = append(errList, &FilterError{Filter: filter, Value: filterValue, Reason: "unknown filter"})
+	}
+	return errList
+}
+
+// stringValue returns filterValue as a string valid for the filter. An
+// empty string is not an error; it leaves the filter unset.
+func (f RequestFilter) stringValue(filterValue interface{}) (string, *FilterError) {
+	param, ok := filterValue.(string)
+	if !ok {
+		return "", f.typeError(filterValue, "string")
+	}
+	if len(param) > 0 && !f.isVlidString(param) {
+		return "", f.valueError(param)
+	}
+	return param, nil
+}
+
+// stringsValue returns filterValue ([]string or string) as a list of
+// strings valid for the filter, and the errors of the invalid elements.
+// set is false if the filter is to be left unchanged.
+func (f RequestFilter) stringsValue(filterValue interface{}) (params []string, set bool, errList []*FilterError) {
+	switch v := filterValue.(type) {
+	case []string:
+		params = []string{}
+		for _, param := range v {
+			if f.isVlidString(param) {
+				params = append(params, param)
+			} else {
+				errList = append(errList, f.valueError(param))
+			}
+		}
+		return params, true, errList
+	case string:
+		if len(v) == 0 {
+			return nil, false, nil
 		}
+		if !f.isVlidString(v) {
+			return nil, false, []*FilterError{f.valueError(v)}
+		}
+		return []string{v}, true, nil
 	}
+	return nil, false, []*FilterError{f.typeError(filterValue, "[]string or string")}
+}
 
+// intValue returns filterValue as an int in [min, max] (no upper bound if
+// max is 0).
+func (f RequestFilter) intValue(filterValue interface{}, min, max int) (int, *FilterError) {
+	n, ok := filterValue.(int)
+	if !ok {
+		return 0, f.typeError(filterValue, "int")
+	}
+	if n < min || (max > 0 && n > max) {
+		reason := fmt.Sprintf("must be between %d and %d", min, max)
+		if max == 0 {
+			reason = fmt.Sprintf("must be at least %d", min)
+		}
+		return 0, &FilterError{Filter: f, Value: filterValue, Reason: reason}
+	}
+	return n, nil
+}
+
+func (f RequestFilter) typeError(filterValue interface{}, want string) *FilterError {
+	return &FilterError{Filter: f, Value: filterValue, Reason: fmt.Sprintf("unexpected type %T, want %s", filterValue, want)}
+}
+
+func (f RequestFilter) valueError(value string) *FilterError {
+	err := &FilterError{Filter: f, Value: value, Reason: "invalid value", Allowed: validationMap[f]}
+	switch f {
+	case BrowseNodeID, BrowseNodeIds:
+		err.Reason = "invalid value, want a numeric browse node ID"
+	case Availability, Condition, DeliveryFlags, ItemIdType, PartnerType, SearchIndex, SortBy:
+	default:
+		err.Reason = "empty value"
+	}
+	return err
 }
 
 /* Copyright 2019-2022 Spiegel and contributors
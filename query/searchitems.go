@@ -54,15 +54,24 @@ var (
 func (q *SearchItems) Request(request RequestFilter, value interface{}) *SearchItems {
 	if request.findIn(requestsOfSearchItems) {
 		q.With().RequestFilters(RequestMap{request: value})
+	} else {
+		q.With().unsupported(request, value, requestsOfSearchItems)
 	}
 	return q
 }
 
+// Strict sets the strict mode, in which Payload fails on rejected filters (see Query.Validate)
+func (q *SearchItems) Strict() *SearchItems {
+	q.With().Strict()
+	return q
+}
+
 // Search is a generic search query funtion to obtain informations from the "SearchItems"-operation
 func (q *SearchItems) Search(searchType RequestFilter, searchParam string) *SearchItems {
 	if searchType.findIn(searchTypes) {
 		return q.Request(searchType, searchParam)
 	}
+	q.With().unsupported(searchType, searchParam, searchTypes)
 	return q
 }
 
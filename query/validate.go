@@ -0,0 +1,99 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goark/errs"
+	paapi5 "github.com/goark/pa-api"
+)
+
+// FilterError is a filter value rejected by a query
+type FilterError struct {
+	Filter  RequestFilter
+	Value   interface{}
+	Reason  string
+	Allowed []string // allowed values, if the filter has a fixed set
+}
+
+// Error method returns error message.
+// This method is a implementation of error interface.
+func (e *FilterError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	msg := fmt.Sprintf("filter %v (%#v): %s", e.Filter, e.Value, e.Reason)
+	if len(e.Allowed) > 0 {
+		msg += fmt.Sprintf(" (allowed: %s)", strings.Join(e.Allowed, ", "))
+	}
+	return msg
+}
+
+// Unwrap method returns paapi5.ErrInvalidQuery
+func (e *FilterError) Unwrap() error {
+	return paapi5.ErrInvalidQuery
+}
+
+// Validate returns the filters rejected by the query so far, joined into
+// one error, or nil if every filter was accepted. Rejected filters are not
+// included in the payload.
+func (q *Query) Validate() error {
+	if q == nil {
+		return errs.Wrap(paapi5.ErrNullPointer)
+	}
+	errList := make([]error, 0, len(q.rejected))
+	for _, e := range q.rejected {
+		errList = append(errList, e)
+	}
+	return errs.Join(errList...)
+}
+
+// Strict sets the strict mode, in which Payload fails if the query has
+// rejected any filter (see Validate).
+func (q *Query) Strict() *Query {
+	q.strict = true
+	return q
+}
+
+// reject records the rejected values of a filter, replacing those recorded
+// for it before: only the latest value given to a filter counts, so a
+// filter accepted after a rejection (errList empty) has no rejection left.
+func (q *Query) reject(filter RequestFilter, errList ...*FilterError) {
+	// A new slice, as shallow copies of the query share the old one.
+	kept := make([]*FilterError, 0, len(q.rejected)+len(errList))
+	for _, e := range q.rejected {
+		if e.Filter != filter {
+			kept = append(kept, e)
+		}
+	}
+	q.rejected = append(kept, errList...)
+}
+
+// unsupported records a filter not supported by the operation of the query
+func (q *Query) unsupported(filter RequestFilter, value interface{}, supported []RequestFilter) {
+	names := make([]string, 0, len(supported))
+	for _, f := range supported {
+		names = append(names, f.String())
+	}
+	q.reject(filter, &FilterError{
+		Filter:  filter,
+		Value:   value,
+		Reason:  fmt.Sprintf("not supported by %v", q.Operation()),
+		Allowed: names,
+	})
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
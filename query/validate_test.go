@@ -0,0 +1,176 @@
+package query
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	paapi5 "github.com/goark/pa-api"
+)
+
+func TestValidate(t *testing.T) {
+	q := NewSearchItems("www.amazon.com", "mytag-20", "Associates").
+		Search(Keywords, "golang").
+		Request(SearchIndex, "Bookz").
+		Request(SortBy, 3).
+		Request(ItemCount, 11).
+		Request(DeliveryFlags, []string{"Prime", "Teleport"}).
+		Request(ItemIds, []string{"4900900028"}).
+		Search(ASIN, "4900900028")
+
+	err := q.Validate()
+	if !errors.Is(err, paapi5.ErrInvalidQuery) {
+		t.Fatalf("Validate() = %v, want %v", err, paapi5.ErrInvalidQuery)
+	}
+	msg := err.Error()
+	for _, want := range []string{
+		`filter SearchIndex ("Bookz"): invalid value (allowed: All, AmazonVideo,`,
+		`filter SortBy (3): unexpected type int, want string`,
+		`filter ItemCount (11): must be between 1 and 10`,
+		`filter DeliveryFlags ("Teleport"): invalid value (allowed: AmazonGlobal, FreeShipping, FulfilledByAmazon, Prime)`,
+		`filter ItemIds ([]string{"4900900028"}): not supported by SearchItems (allowed: Actor, Artist,`,
+		`filter ASIN ("4900900028"): not supported by SearchItems (allowed: Actor, Artist, Author, Brand, Keywords, Title)`,
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Validate() = %q, want it to contain %q", msg, want)
+		}
+	}
+	var ferr *FilterError
+	if !errors.As(err, &ferr) || ferr.Filter != SearchIndex {
+		t.Errorf("errors.As(FilterError) = %+v", ferr)
+	}
+
+	// Non-strict mode fails on a rejected numeric filter (see CheckConstraints)...
+	var cerr *ConstraintError
+	if _, err := q.Payload(); !errors.As(err, &cerr) || cerr.Filters[0] != ItemCount {
+		t.Errorf("Payload() error = %v, want a ConstraintError of ItemCount", err)
+	}
+
+	// ...and otherwise keeps the accepted filters and drops the rejected ones.
+	q.Request(ItemCount, 10)
+	b, err := q.Payload()
+	if err != nil {
+		t.Fatalf("Payload() error = %+v", err)
+	}
+	if got, want := string(b), `{"deliveryFlags":["Prime"],"itemCount":10,"keywords":"golang","partnerTag":"mytag-20"}`; got != want {
+		t.Errorf("Payload() = %s, want %s", got, want)
+	}
+
+	// Strict mode fails.
+	if _, err := q.Strict().Payload(); !errors.Is(err, paapi5.ErrInvalidQuery) {
+		t.Errorf("strict Payload() error = %v, want %v", err, paapi5.ErrInvalidQuery)
+	}
+}
+
+func TestValidateAccepted(t *testing.T) {
+	testCases := []paapi5.Query{
+		NewGetItems("www.amazon.com", "mytag-20", "Associates").ASINs([]string{"4900900028"}).EnableItemInfo().Strict(),
+		NewGetItems("", "", "").Strict(),
+		NewGetVariations("www.amazon.com", "mytag-20", "Associates").ASIN("B07YCM5K55").Request(VariationPage, 2).Strict(),
+		NewGetBrowseNodes("www.amazon.com", "mytag-20", "Associates").BrowseNodeIds([]string{"3040"}).Strict(),
+		NewSearchItems("www.amazon.com", "mytag-20", "Associates").Search(Keywords, "golang").Request(Properties, map[string]string{"a": "b"}).Strict(),
+	}
+	for _, q := range testCases {
+		if _, err := q.Payload(); err != nil {
+			t.Errorf("Payload() of %v error = %+v", q.Operation(), err)
+		}
+		if err := q.(interface{ Validate() error }).Validate(); err != nil {
+			t.Errorf("Validate() of %v = %+v", q.Operation(), err)
+		}
+	}
+	if err := (*Query)(nil).Validate(); !errors.Is(err, paapi5.ErrNullPointer) {
+		t.Errorf("Validate() of nil query = %v, want %v", err, paapi5.ErrNullPointer)
+	}
+}
+
+func TestValidateRequestFilters(t *testing.T) {
+	q := New(paapi5.GetItems).RequestFilters(RequestMap{
+		RequestFilter(0):      "foo",
+		BrowseNodeID:          "abc",
+		Properties:            "foo",
+		MinPrice:              0,
+		MinReviewsRating:      5,
+		LanguagesOfPreference: []string{"en_US", ""},
+		Keywords:              "",
+	})
+	errList := []string{
+		`filter RequestFilter(0) ("foo"): unknown filter`,
+		`filter BrowseNodeID ("abc"): invalid value, want a numeric browse node ID`,
+		`filter LanguagesOfPreference (""): empty value`,
+		`filter MinPrice (0): must be at least 1`,
+		`filter MinReviewsRating (5): must be between 1 and 4`,
+		`filter Properties ("foo"): unexpected type string, want map[string]string`,
+	}
+	if got, want := q.Validate().Error(), strings.Join(errList, "\n"); got != want {
+		t.Errorf("Validate() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestValidateReaccepted(t *testing.T) {
+	q := NewSearchItems("www.amazon.com", "mytag-20", "Associates").
+		Search(Keywords, "golang").
+		Request(SearchIndex, "Bookz").
+		Request(ItemCount, 11).
+		Request(SearchIndex, "Books").
+		Strict()
+	if err := q.Validate(); err == nil || !strings.Contains(err.Error(), "filter ItemCount (11)") || strings.Contains(err.Error(), "SearchIndex") {
+		t.Errorf("Validate() = %v, want only the ItemCount rejection", err)
+	}
+	q.Request(ItemCount, 10)
+	if err := q.Validate(); err != nil {
+		t.Errorf("Validate() = %+v, want nil", err)
+	}
+	if _, err := q.Payload(); err != nil {
+		t.Errorf("strict Payload() error = %+v", err)
+	}
+
+	// A rejection after an accepted value is recorded again; the accepted
+	// value stays in the payload.
+	q.Request(SearchIndex, "Bookz")
+	if err := q.Validate(); err == nil || !strings.Contains(err.Error(), `filter SearchIndex ("Bookz")`) {
+		t.Errorf("Validate() = %v, want the SearchIndex rejection", err)
+	}
+}
+
+func TestValidateRemovedFilters(t *testing.T) {
+	q := NewSearchItems("www.amazon.com", "mytag-20", "Associates").
+		Search(Keywords, "golang").
+		Request(Merchant, "Amazon").
+		Request(OfferCount, 1)
+	errList := []string{
+		`filter Merchant ("Amazon"): not accepted by the Creators API`,
+		`filter OfferCount (1): not accepted by the Creators API`,
+	}
+	if got, want := q.Validate().Error(), strings.Join(errList, "\n"); got != want {
+		t.Errorf("Validate() =\n%s\nwant\n%s", got, want)
+	}
+	if _, err := q.Strict().Payload(); !errors.Is(err, paapi5.ErrInvalidQuery) {
+		t.Errorf("strict Payload() error = %v, want %v", err, paapi5.ErrInvalidQuery)
+	}
+
+	// Marketplace and PartnerType are set by the constructors and dropped
+	// without a rejection.
+	q = NewSearchItems("www.amazon.com", "mytag-20", "Associates").
+		Search(Keywords, "golang").
+		Request(Marketplace, "www.amazon.co.jp").
+		Request(PartnerType, "Associates").
+		Strict()
+	if err := q.Validate(); err != nil {
+		t.Errorf("Validate() = %+v, want nil", err)
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
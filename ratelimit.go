@@ -0,0 +1,195 @@
+package paapi5
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/goark/errs"
+)
+
+// dailyQuotaWindow is the length of the window the per-day quota applies to.
+const dailyQuotaWindow = 24 * time.Hour
+
+// rateLimiter is a token bucket limiting the request rate (TPS) of a client,
+// combined with an optional per-day request quota (TPD). It is safe for
+// concurrent use; all requests through the same client share one instance.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second; zero or less means unlimited
+	burst  int
+	tokens float64
+	last   time.Time
+
+	perDay   int // requests per day; zero or less means unlimited
+	dayStart time.Time
+	dayUsed  int
+
+	now func() time.Time
+}
+
+// newRateLimiter constructs a rateLimiter. burst below 1 is raised to 1.
+func newRateLimiter(tps float64, burst, perDay int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   tps,
+		burst:  burst,
+		tokens: float64(burst),
+		perDay: perDay,
+		now:    time.Now,
+	}
+}
+
+// WithRateLimit function returns a ClientOptFunc that limits the client to
+// tps requests per second with bursts of up to burst requests, and to
+// perDay requests per 24 hours. Pass zero for tps or perDay to leave that
+// dimension unlimited.
+//
+// Requests block until a token is available or the context is done. A
+// request that cannot be served within the context deadline, or once the
+// daily quota is spent, fails immediately with ErrTooManyRequests in its
+// error chain.
+func WithRateLimit(tps float64, burst, perDay int) ClientOptFunc {
+	return func(c *client) {
+		if c != nil && (tps > 0 || perDay > 0) {
+			c.limiter = newRateLimiter(tps, burst, perDay)
+		}
+	}
+}
+
+// Wait blocks until the request may proceed.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	delay, err := l.reserve(ctx)
+	if err != nil || delay <= 0 {
+		return err
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return errs.Wrap(ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes one token and one unit of daily quota and returns how long
+// the caller has to wait before using them.
+func (l *rateLimiter) reserve(ctx context.Context) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if l.perDay > 0 {
+		if l.dayStart.IsZero() || now.Sub(l.dayStart) >= dailyQuotaWindow {
+			l.dayStart = now
+			l.dayUsed = 0
+		}
+		if l.dayUsed >= l.perDay {
+			return 0, errs.Wrap(ErrTooManyRequests,
+				errs.WithContext("reason", "daily quota exhausted"),
+				errs.WithContext("reset", l.dayStart.Add(dailyQuotaWindow).Format(time.RFC3339)),
+			)
+		}
+	}
+	var delay time.Duration
+	if l.rate > 0 {
+		l.advance(now)
+		if l.tokens < 1 {
+			delay = time.Duration(math.Ceil((1 - l.tokens) / l.rate * float64(time.Second)))
+		}
+		if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+			return 0, errs.Wrap(ErrTooManyRequests,
+				errs.WithContext("reason", "rate limit wait exceeds context deadline"),
+				errs.WithContext("wait", delay.String()),
+			)
+		}
+		l.tokens--
+	}
+	l.dayUsed++
+	return delay, nil
+}
+
+// cancel gives back a reservation whose wait was abandoned.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate > 0 {
+		l.advance(l.now())
+		l.tokens = math.Min(l.tokens+1, float64(l.burst))
+	}
+	if l.perDay > 0 && l.dayUsed > 0 {
+		l.dayUsed--
+	}
+}
+
+// advance refills the bucket for the time elapsed since the last update.
+func (l *rateLimiter) advance(now time.Time) {
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens = math.Min(l.tokens+now.Sub(l.last).Seconds()*l.rate, float64(l.burst))
+	}
+	l.last = now
+}
+
+// RemainingDaily returns the number of requests left in the current daily
+// window. The second result is false when no daily quota is configured.
+func (l *rateLimiter) RemainingDaily() (int, bool) {
+	if l == nil || l.perDay <= 0 {
+		return 0, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.dayStart.IsZero() || l.now().Sub(l.dayStart) >= dailyQuotaWindow {
+		return l.perDay, true
+	}
+	if remaining := l.perDay - l.dayUsed; remaining > 0 {
+		return remaining, true
+	}
+	return 0, true
+}
+
+// dailyQuotaReporter is an optional interface satisfied by Client
+// implementations that can report their remaining daily quota. The client
+// returned by Server.CreateClient satisfies it; wrappers may opt in.
+type dailyQuotaReporter interface {
+	RemainingDailyQuota() (int, bool)
+}
+
+// RemainingDailyQuota method returns the number of requests left in the
+// current daily window configured by WithRateLimit. The second result is
+// false when no daily quota is configured.
+func (c *client) RemainingDailyQuota() (int, bool) {
+	return c.limiter.RemainingDaily()
+}
+
+// RemainingDailyQuota function returns the number of requests the Client
+// may still issue in the current daily window configured by WithRateLimit.
+// The second result is false when the Client has no daily quota configured
+// or does not report one.
+func RemainingDailyQuota(c Client) (int, bool) {
+	if r, ok := c.(dailyQuotaReporter); ok {
+		return r.RemainingDailyQuota()
+	}
+	return 0, false
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,148 @@
+package paapi5
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(2, 2, 0)
+	l.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for i, want := range []time.Duration{0, 0, 500 * time.Millisecond, time.Second} {
+		got, err := l.reserve(ctx)
+		if err != nil {
+			t.Fatalf("reserve #%d: %v", i, err)
+		}
+		if got != want {
+			t.Errorf("reserve #%d wait = %v, want %v", i, got, want)
+		}
+	}
+	// After one second the two pending reservations are paid off and the
+	// bucket is empty again.
+	now = now.Add(time.Second)
+	if got, err := l.reserve(ctx); err != nil || got != 500*time.Millisecond {
+		t.Errorf("reserve after refill = %v, %v, want %v, nil", got, err, 500*time.Millisecond)
+	}
+}
+
+func TestRateLimiterDeadline(t *testing.T) {
+	l := newRateLimiter(1, 1, 0)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("first Wait: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := l.Wait(ctx)
+	if !errors.Is(err, ErrTooManyRequests) {
+		t.Errorf("Wait beyond deadline error = %v, want ErrTooManyRequests", err)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("Wait beyond deadline blocked for %v", elapsed)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	l := newRateLimiter(1, 1, 10)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("first Wait: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait error = %v, want context.Canceled", err)
+	}
+	if got, _ := l.RemainingDaily(); got != 9 {
+		t.Errorf("RemainingDaily() after canceled wait = %d, want 9", got)
+	}
+}
+
+func TestRateLimiterDailyQuota(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := newRateLimiter(0, 0, 2)
+	l.now = func() time.Time { return now }
+
+	if got, ok := l.RemainingDaily(); !ok || got != 2 {
+		t.Errorf("RemainingDaily() = %d, %v, want 2, true", got, ok)
+	}
+	ctx := context.Background()
+	for i := range 2 {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatalf("Wait #%d: %v", i, err)
+		}
+	}
+	if got, ok := l.RemainingDaily(); !ok || got != 0 {
+		t.Errorf("RemainingDaily() = %d, %v, want 0, true", got, ok)
+	}
+	if err := l.Wait(ctx); !errors.Is(err, ErrTooManyRequests) {
+		t.Errorf("Wait over daily quota error = %v, want ErrTooManyRequests", err)
+	}
+	now = now.Add(dailyQuotaWindow)
+	if got, ok := l.RemainingDaily(); !ok || got != 2 {
+		t.Errorf("RemainingDaily() after reset = %d, %v, want 2, true", got, ok)
+	}
+	if err := l.Wait(ctx); err != nil {
+		t.Errorf("Wait after reset: %v", err)
+	}
+}
+
+func TestClientRateLimitSharedAcrossGoroutines(t *testing.T) {
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}
+	_, _, sv := newServers(t, okTokenHandler, apiHandler)
+	c := sv.CreateClient("tag", "id", "secret", WithRateLimit(20, 1, 100))
+
+	const n = 5
+	start := time.Now()
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.RequestContext(context.Background(), stubQuery{op: GetItems, payload: []byte("{}")}); err != nil {
+				t.Errorf("RequestContext: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	// 5 requests at 20 TPS with burst 1 need at least 4 * 50ms.
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("%d requests finished in %v, want rate limited", n, elapsed)
+	}
+	if got, ok := RemainingDailyQuota(c); !ok || got != 100-n {
+		t.Errorf("RemainingDailyQuota() = %d, %v, want %d, true", got, ok, 100-n)
+	}
+}
+
+func TestRemainingDailyQuotaWithoutLimit(t *testing.T) {
+	c := New().CreateClient("tag", "id", "secret")
+	if got, ok := RemainingDailyQuota(c); ok || got != 0 {
+		t.Errorf("RemainingDailyQuota() = %d, %v, want 0, false", got, ok)
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,161 @@
+package paapi5
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/goark/errs"
+)
+
+const (
+	defaultRetryMaxAttempts    = 4
+	defaultRetryInitialBackoff = 1 * time.Second
+	defaultRetryMaxBackoff     = 30 * time.Second
+)
+
+// RetryPolicy configures automatic retries of throttled (HTTP 429) and
+// transient (HTTP 5xx and network) failures. Other 4xx replies, such as
+// validation errors, are never retried.
+//
+// Backoff grows exponentially from InitialBackoff and is capped by
+// MaxBackoff; each wait is jittered to avoid synchronized retries across
+// workers. A Retry-After header sent by the API takes precedence over the
+// computed backoff.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the base delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the computed delay between attempts.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used when WithRetryPolicy is
+// given a zero value: 4 attempts, 1 second initial backoff and 30 seconds
+// maximum backoff.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    defaultRetryMaxAttempts,
+		InitialBackoff: defaultRetryInitialBackoff,
+		MaxBackoff:     defaultRetryMaxBackoff,
+	}
+}
+
+// WithRetryPolicy function returns a ClientOptFunc that enables automatic
+// retries of throttled and transient failures. Zero fields of policy fall
+// back to the values of DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) ClientOptFunc {
+	return func(c *client) {
+		if c != nil {
+			p := policy.normalize()
+			c.retry = &p
+		}
+	}
+}
+
+// normalize fills zero fields with default values.
+func (p RetryPolicy) normalize() RetryPolicy {
+	dflt := DefaultRetryPolicy()
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = dflt.MaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = dflt.InitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = dflt.MaxBackoff
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = p.InitialBackoff
+	}
+	return p
+}
+
+// backoff returns the delay to wait after the given (1-based) failed attempt.
+// The exponential delay is jittered into the range [d/2, d].
+func (p RetryPolicy) backoff(attempt int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter
+	}
+	d := p.InitialBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + rand.N(d-half+1) //nolint:gosec // G404: jitter does not need a cryptographic source.
+}
+
+// isRetryable reports whether err is a throttled or transient failure.
+func isRetryable(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// postWithRetry calls post, retrying throttled and transient failures
+// according to the configured RetryPolicy.
+func (c *client) postWithRetry(ctx context.Context, cmd Operation, payload []byte) (io.ReadCloser, error) {
+	for attempt := 1; ; attempt++ {
+		body, err := c.post(ctx, cmd, payload)
+		if err == nil {
+			return body, nil
+		}
+		if c.retry == nil || attempt >= c.retry.MaxAttempts || !isRetryable(ctx, err) {
+			if attempt > 1 {
+				return nil, errs.Wrap(err, errs.WithContext("attempts", attempt))
+			}
+			return nil, err
+		}
+		wait := c.retry.backoff(attempt, err)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			// Waiting would outlive the context; give up with the last failure.
+			return nil, errs.Wrap(err, errs.WithContext("attempts", attempt))
+		}
+		c.logRetry(ctx, cmd, attempt, wait, err)
+		pickTrace(ctx, c.trace).retry(ctx, cmd, attempt, wait, err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, errs.Wrap(ctx.Err(), errs.WithCause(err), errs.WithContext("attempts", attempt))
+		case <-timer.C:
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,191 @@
+package paapi5
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func okTokenHandler(w http.ResponseWriter, r *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": "tok",
+		"expires_in":   3600,
+	})
+}
+
+func fastRetryPolicy(attempts int) RetryPolicy {
+	return RetryPolicy{MaxAttempts: attempts, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+}
+
+func TestRetryThrottledThenSuccess(t *testing.T) {
+	var apiCalls int32
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&apiCalls, 1) < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"errors":[{"code":"TooManyRequests","message":"throttled"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"itemsResult":{}}`))
+	}
+	_, _, sv := newServers(t, okTokenHandler, apiHandler)
+	c := sv.CreateClient("tag", "id", "secret", WithRetryPolicy(fastRetryPolicy(4)))
+
+	body, err := c.RequestContext(context.Background(), stubQuery{op: GetItems, payload: []byte("{}")})
+	if err != nil {
+		t.Fatalf("RequestContext: %+v", err)
+	}
+	if got, want := string(body), `{"itemsResult":{}}`; got != want {
+		t.Errorf("response body = %q, want %q", got, want)
+	}
+	if got, want := atomic.LoadInt32(&apiCalls), int32(3); got != want {
+		t.Errorf("api endpoint hit %d times, want %d", got, want)
+	}
+}
+
+func TestRetryServerErrorExhausted(t *testing.T) {
+	var apiCalls int32
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&apiCalls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, _, sv := newServers(t, okTokenHandler, apiHandler)
+	c := sv.CreateClient("tag", "id", "secret", WithRetryPolicy(fastRetryPolicy(3)))
+
+	_, err := c.RequestContext(context.Background(), stubQuery{op: GetItems, payload: []byte("{}")})
+	if !errors.Is(err, ErrHTTPStatus) {
+		t.Errorf("error chain missing ErrHTTPStatus: %v", err)
+	}
+	if got, want := atomic.LoadInt32(&apiCalls), int32(3); got != want {
+		t.Errorf("api endpoint hit %d times, want %d", got, want)
+	}
+}
+
+func TestRetryNeverRetriesValidationErrors(t *testing.T) {
+	var apiCalls int32
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&apiCalls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errors":[{"code":"InvalidParameterValue","message":"bad"}]}`))
+	}
+	_, _, sv := newServers(t, okTokenHandler, apiHandler)
+	c := sv.CreateClient("tag", "id", "secret", WithRetryPolicy(fastRetryPolicy(5)))
+
+	_, err := c.RequestContext(context.Background(), stubQuery{op: GetItems, payload: []byte("{}")})
+	if err == nil {
+		t.Fatal("expected API error, got nil")
+	}
+	if got, want := atomic.LoadInt32(&apiCalls), int32(1); got != want {
+		t.Errorf("api endpoint hit %d times, want %d", got, want)
+	}
+}
+
+func TestRetryNetworkError(t *testing.T) {
+	var apiCalls int32
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&apiCalls, 1) == 1 {
+			hj, ok := w.(http.Hijacker)
+			if !ok {
+				t.Fatal("ResponseWriter is not http.Hijacker")
+			}
+			conn, _, err := hj.Hijack()
+			if err != nil {
+				t.Fatalf("Hijack: %v", err)
+			}
+			_ = conn.Close()
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}
+	_, _, sv := newServers(t, okTokenHandler, apiHandler)
+	c := sv.CreateClient("tag", "id", "secret", WithRetryPolicy(fastRetryPolicy(2)))
+
+	if _, err := c.RequestContext(context.Background(), stubQuery{op: GetItems, payload: []byte("{}")}); err != nil {
+		t.Fatalf("RequestContext: %+v", err)
+	}
+	if got, want := atomic.LoadInt32(&apiCalls), int32(2); got != want {
+		t.Errorf("api endpoint hit %d times, want %d", got, want)
+	}
+}
+
+func TestRetryAfterBeyondDeadline(t *testing.T) {
+	var apiCalls int32
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&apiCalls, 1)
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}
+	_, _, sv := newServers(t, okTokenHandler, apiHandler)
+	c := sv.CreateClient("tag", "id", "secret", WithRetryPolicy(fastRetryPolicy(5)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err := c.RequestContext(ctx, stubQuery{op: GetItems, payload: []byte("{}")})
+	if !errors.Is(err, ErrTooManyRequests) {
+		t.Errorf("error chain missing ErrTooManyRequests: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("RequestContext waited %v, want to give up before the deadline", elapsed)
+	}
+	if got, want := atomic.LoadInt32(&apiCalls), int32(1); got != want {
+		t.Errorf("api endpoint hit %d times, want %d", got, want)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}.normalize()
+	testCases := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{attempt: 1, min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{attempt: 2, min: 100 * time.Millisecond, max: 200 * time.Millisecond},
+		{attempt: 3, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{attempt: 10, min: 500 * time.Millisecond, max: time.Second},
+	}
+	for _, tc := range testCases {
+		for range 20 {
+			if d := p.backoff(tc.attempt, nil); d < tc.min || d > tc.max {
+				t.Errorf("backoff(%d) = %v, want in [%v, %v]", tc.attempt, d, tc.min, tc.max)
+			}
+		}
+	}
+	apiErr := &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 3 * time.Second}
+	if got, want := p.backoff(1, apiErr), 3*time.Second; got != want {
+		t.Errorf("backoff with Retry-After = %v, want %v", got, want)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got, want := parseRetryAfter("5"), 5*time.Second; got != want {
+		t.Errorf("parseRetryAfter(\"5\") = %v, want %v", got, want)
+	}
+	for _, v := range []string{"", "0", "-1", "soon"} {
+		if got := parseRetryAfter(v); got != 0 {
+			t.Errorf("parseRetryAfter(%q) = %v, want 0", v, got)
+		}
+	}
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(future); got <= 0 || got > time.Hour {
+		t.Errorf("parseRetryAfter(%q) = %v, want in (0, 1h]", future, got)
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -4,8 +4,6 @@ import (
 	"context"
 	"net/http"
 	"net/url"
-
-	"github.com/goark/fetch"
 )
 
 const (
@@ -276,16 +274,24 @@ func (s *Server) CreateClient(associateTag, credentialID, credentialSecret strin
 		opt(cli)
 	}
 	if cli.httpClient == nil {
-		cli.httpClient = fetch.New()
-	}
-	if cli.tokenHTTPClient == nil {
-		cli.tokenHTTPClient = http.DefaultClient
+		cli.httpClient = http.DefaultClient
 	}
 	if len(cli.authEndpoint) == 0 {
 		cli.authEndpoint = AuthEndpointFor(cli.version)
 	}
 	cli.lwaFlow = isLWACredentialVersion(cli.version)
-	cli.auth = newTokenManager(cli.tokenHTTPClient, cli.authEndpoint, cli.credentialID, cli.credentialSecret, cli.lwaFlow)
+	if cli.auth == nil {
+		tm := newTokenManager(cli.httpClient, cli.authEndpoint, cli.credentialID, cli.credentialSecret, cli.lwaFlow)
+		if len(cli.tokenCacheDir) > 0 {
+			tm.cache = newFileTokenCache(cli.tokenCacheDir)
+		}
+		tm.refreshAhead = cli.tokenRefreshAhead
+		tm.logger = cli.logger
+		tm.trace = cli.trace
+		cli.auth = tm
+		cli.closer = tm
+	}
+	cli.chain = cli.buildChain()
 	return cli
 }
 
@@ -302,8 +308,7 @@ func WithContext(ctx context.Context) ClientOptFunc {
 func WithHttpClient(hc *http.Client) ClientOptFunc {
 	return func(c *client) {
 		if c != nil && hc != nil {
-			c.httpClient = fetch.New(fetch.WithHTTPClient(hc))
-			c.tokenHTTPClient = hc
+			c.httpClient = hc
 		}
 	}
 }
@@ -0,0 +1,175 @@
+package paapi5
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/goark/errs"
+)
+
+const (
+	// tokenCacheDirPerm and tokenCacheFilePerm restrict the on-disk token
+	// cache to the owner of the process.
+	tokenCacheDirPerm  = 0o700
+	tokenCacheFilePerm = 0o600
+	// tokenCacheLockPoll is the interval between attempts to take the
+	// cache file lock.
+	tokenCacheLockPoll = 10 * time.Millisecond
+)
+
+// cachedToken is the on-disk representation of a cached access token.
+// ExpiresAt already accounts for oauthTokenLeewaySeconds.
+type cachedToken struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// fileTokenCache persists access tokens under a directory, one file per
+// credential ID + token endpoint + scope, so that separate processes using
+// the same credentials can reuse a token instead of performing a fresh
+// OAuth2 exchange. Access to an entry is serialised with a file lock.
+type fileTokenCache struct {
+	dir string
+}
+
+// newFileTokenCache constructs a fileTokenCache rooted at dir.
+func newFileTokenCache(dir string) *fileTokenCache {
+	return &fileTokenCache{dir: dir}
+}
+
+// DefaultTokenCacheDir returns the default directory for WithTokenCacheDir,
+// located under the user's cache directory (see os.UserCacheDir).
+func DefaultTokenCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+	return filepath.Join(dir, "goark-pa-api", "tokens"), nil
+}
+
+// WithTokenCacheDir function returns a ClientOptFunc that persists OAuth2
+// access tokens under dir, so that CLI tools and cron jobs sharing the same
+// credentials reuse a still-valid token across processes. The directory is
+// created with owner-only permissions if it does not exist. The option has
+// no effect when the client uses a TokenSource set by WithTokenSource.
+func WithTokenCacheDir(dir string) ClientOptFunc {
+	return func(c *client) {
+		if c != nil && len(dir) > 0 {
+			c.tokenCacheDir = dir
+		}
+	}
+}
+
+// key returns the cache key for the supplied credential ID, token endpoint
+// and scope. The key is hashed so that credential IDs do not leak into file
+// names.
+func (fc *fileTokenCache) key(clientID, endpoint, scope string) string {
+	sum := sha256.Sum256([]byte(clientID + "\x00" + endpoint + "\x00" + scope))
+	return hex.EncodeToString(sum[:])
+}
+
+// path returns the path of the cache file for key.
+func (fc *fileTokenCache) path(key string) string {
+	return filepath.Join(fc.dir, key+".json")
+}
+
+// lock takes an exclusive lock on the cache entry for key, polling until the
+// lock is acquired or ctx is done. The returned function releases the lock.
+func (fc *fileTokenCache) lock(ctx context.Context, key string) (func(), error) {
+	if err := os.MkdirAll(fc.dir, tokenCacheDirPerm); err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("dir", fc.dir))
+	}
+	name := filepath.Join(fc.dir, key+".lock")
+	f, err := os.OpenFile(filepath.Clean(name), os.O_RDWR|os.O_CREATE, tokenCacheFilePerm)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("file", name))
+	}
+	for {
+		ok, err := tryLockFile(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, errs.Wrap(err, errs.WithContext("file", name))
+		}
+		if ok {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			_ = f.Close()
+			return nil, errs.Wrap(ctx.Err(), errs.WithContext("file", name))
+		case <-time.After(tokenCacheLockPoll):
+		}
+	}
+	return func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+	}, nil
+}
+
+// load returns the cached token for key if one exists and has not expired.
+func (fc *fileTokenCache) load(key string) (cachedToken, bool) {
+	b, err := os.ReadFile(filepath.Clean(fc.path(key)))
+	if err != nil {
+		return cachedToken{}, false
+	}
+	ct := cachedToken{}
+	if err := json.Unmarshal(b, &ct); err != nil {
+		return cachedToken{}, false
+	}
+	if len(ct.AccessToken) == 0 || !time.Now().Before(ct.ExpiresAt) {
+		return cachedToken{}, false
+	}
+	return ct, true
+}
+
+// store writes the token for key. The file is written to a temporary file
+// and renamed into place so concurrent readers never see a partial entry.
+func (fc *fileTokenCache) store(key string, ct cachedToken) error {
+	if err := os.MkdirAll(fc.dir, tokenCacheDirPerm); err != nil {
+		return errs.Wrap(err, errs.WithContext("dir", fc.dir))
+	}
+	b, err := json.Marshal(ct)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	tmp, err := os.CreateTemp(fc.dir, key+".*.tmp")
+	if err != nil {
+		return errs.Wrap(err, errs.WithContext("dir", fc.dir))
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if err := tmp.Chmod(tokenCacheFilePerm); err != nil {
+		_ = tmp.Close()
+		return errs.Wrap(err, errs.WithContext("file", tmp.Name()))
+	}
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return errs.Wrap(err, errs.WithContext("file", tmp.Name()))
+	}
+	if err := tmp.Close(); err != nil {
+		return errs.Wrap(err, errs.WithContext("file", tmp.Name()))
+	}
+	if err := os.Rename(tmp.Name(), fc.path(key)); err != nil {
+		return errs.Wrap(err, errs.WithContext("file", fc.path(key)))
+	}
+	return nil
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
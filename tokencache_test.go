@@ -0,0 +1,131 @@
+package paapi5
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientTokenCacheDirSharedAcrossClients(t *testing.T) {
+	var tokenCalls int32
+	tokenHandler := func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenCalls, 1)
+		okTokenHandler(w, r)
+	}
+	apiHandler := func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("Authorization"), "Bearer tok"; got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+		_, _ = w.Write([]byte("{}"))
+	}
+	_, _, sv := newServers(t, tokenHandler, apiHandler)
+	dir := filepath.Join(t.TempDir(), "tokens")
+
+	// Each client stands in for a separate process starting cold.
+	q := stubQuery{op: GetItems, payload: []byte("{}")}
+	for range 3 {
+		c := sv.CreateClient("tag", "id", "secret", WithTokenCacheDir(dir))
+		if _, err := c.RequestContext(context.Background(), q); err != nil {
+			t.Fatalf("RequestContext: %v", err)
+		}
+	}
+	if got, want := atomic.LoadInt32(&tokenCalls), int32(1); got != want {
+		t.Errorf("token endpoint hit %d times, want %d", got, want)
+	}
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	fi, err := os.Stat(dir)
+	if err != nil {
+		t.Fatalf("Stat(%q): %v", dir, err)
+	}
+	if got, want := fi.Mode().Perm(), os.FileMode(tokenCacheDirPerm); got != want {
+		t.Errorf("cache dir mode = %v, want %v", got, want)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("cache files = %v, %v, want exactly one", files, err)
+	}
+	fi, err = os.Stat(files[0])
+	if err != nil {
+		t.Fatalf("Stat(%q): %v", files[0], err)
+	}
+	if got, want := fi.Mode().Perm(), os.FileMode(tokenCacheFilePerm); got != want {
+		t.Errorf("cache file mode = %v, want %v", got, want)
+	}
+}
+
+func TestFileTokenCacheExpiry(t *testing.T) {
+	fc := newFileTokenCache(t.TempDir())
+	key := fc.key("id", "https://example.test/token", oauthScopeLWA)
+
+	if _, ok := fc.load(key); ok {
+		t.Error("load() on empty cache reported a token")
+	}
+	if err := fc.store(key, cachedToken{AccessToken: "old", ExpiresAt: time.Now().Add(-time.Second)}); err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	if _, ok := fc.load(key); ok {
+		t.Error("load() returned an expired token")
+	}
+	if err := fc.store(key, cachedToken{AccessToken: "new", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	if ct, ok := fc.load(key); !ok || ct.AccessToken != "new" {
+		t.Errorf("load() = %+v, %v, want token \"new\"", ct, ok)
+	}
+}
+
+func TestFileTokenCacheKey(t *testing.T) {
+	fc := newFileTokenCache(t.TempDir())
+	base := fc.key("id", "https://example.test/token", oauthScopeLWA)
+	for _, other := range []string{
+		fc.key("id2", "https://example.test/token", oauthScopeLWA),
+		fc.key("id", "https://example.test/other", oauthScopeLWA),
+		fc.key("id", "https://example.test/token", oauthScopeCognito),
+	} {
+		if other == base {
+			t.Errorf("cache key %q collides with base key", other)
+		}
+	}
+}
+
+func TestFileTokenCacheLockCanceled(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file locks are a no-op on this platform")
+	}
+	fc := newFileTokenCache(t.TempDir())
+	key := fc.key("id", "https://example.test/token", oauthScopeLWA)
+	unlock, err := fc.lock(context.Background(), key)
+	if err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := fc.lock(ctx, key); err == nil {
+		t.Error("second lock() succeeded while the entry was locked")
+	}
+}
+
+/* Copyright 2026 Spiegel and contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */